@@ -0,0 +1,384 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	"context"
+	"errors"
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kufast/objectFactory"
+	"kufast/tools"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ImportCompose translates the services of a docker-compose file into pods or deployments of a tenant-target.
+// Services restarted always or with replicas become deployments, all others pods. Secrets of the compose file become
+// kufast secrets and ports become services named after the compose service. Besides the created objects, all parts
+// of the compose file that could not be imported are returned. With --dry-run, nothing is created.
+// All parameters are drawn from the environment on the command line.
+func ImportCompose(cmd *cobra.Command) ([]string, []string, error) {
+	fileName, _ := cmd.Flags().GetString("file")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	serviceTypeName, _ := cmd.Flags().GetString("type")
+
+	serviceType := v1.ServiceTypeClusterIP
+	if strings.ToLower(serviceTypeName) == "nodeport" {
+		serviceType = v1.ServiceTypeNodePort
+	} else if strings.ToLower(serviceTypeName) != "clusterip" {
+		return nil, nil, errors.New(serviceTypeName + ": Service type has to be either clusterip or nodeport.")
+	}
+
+	composeFile, unsupported, err := tools.ReadComposeFile(fileName)
+	if err != nil {
+		return nil, nil, err
+	}
+	var warnings []string
+	for _, key := range unsupported {
+		warnings = append(warnings, key+": Not supported by kufast.")
+	}
+	composeDir := filepath.Dir(fileName)
+
+	target, _ := cmd.Flags().GetString("target")
+	if target != "" && !IsValidTarget(cmd, target, false) {
+		return nil, nil, errors.New("Invalid target for tenant")
+	}
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+	tenantName := tools.GetTenantFromNamespace(namespaceName)
+	placement, err := GetTenantTargetPlacement(cmd, tenantName, tools.GetTargetFromNamespace(namespaceName))
+	if err != nil {
+		return nil, nil, err
+	}
+	security, err := GetTenantTargetSecurityLevel(cmd, tenantName, tools.GetTargetFromNamespace(namespaceName))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	//Translate secrets
+	var secrets []*v1.Secret
+	for _, name := range sortedKeys(composeFile.Secrets) {
+		composeSecret := composeFile.Secrets[name]
+		var data string
+		if composeSecret.File != "" {
+			content, err := os.ReadFile(composePath(composeDir, composeSecret.File))
+			if err != nil {
+				return nil, nil, err
+			}
+			data = string(content)
+		} else if composeSecret.Environment != "" {
+			data = os.Getenv(composeSecret.Environment)
+		} else {
+			warnings = append(warnings, "secrets."+name+": Only secrets from a file or an environment variable are supported.")
+			continue
+		}
+		secrets = append(secrets, objectFactory.NewSecret(namespaceName, tools.ConvertComposeName(name), data))
+	}
+
+	//Translate services
+	var pods []*v1.Pod
+	var deployments []*appsv1.Deployment
+	var services []*v1.Service
+	for _, serviceName := range sortedKeys(composeFile.Services) {
+		composeService := composeFile.Services[serviceName]
+		prefix := "services." + serviceName
+		name := tools.ConvertComposeName(serviceName)
+		if name != serviceName {
+			warnings = append(warnings, prefix+": Renamed to "+name+" to be a valid name.")
+		}
+		if composeService.Image == "" {
+			warnings = append(warnings, prefix+": Skipped, as it has no image. Building images is not supported.")
+			continue
+		}
+
+		//The service keeps the name of the compose service, so other services still reach it by this name
+		workloadName := name
+		if composeService.ContainerName != "" {
+			workloadName = tools.ConvertComposeName(composeService.ContainerName)
+			if workloadName != composeService.ContainerName {
+				warnings = append(warnings, prefix+".container_name: Renamed to "+workloadName+" to be a valid name.")
+			}
+		}
+
+		podObject, ports, serviceWarnings, err := newPodFromComposeService(cmd, workloadName, namespaceName, composeDir, composeService)
+		if err != nil {
+			return nil, nil, errors.New(prefix + ": " + err.Error())
+		}
+		for _, warning := range serviceWarnings {
+			warnings = append(warnings, prefix+"."+warning)
+		}
+		objectFactory.ApplyPlacementPolicy(&podObject.Spec, tenantName, placement)
+		objectFactory.ApplySecurityLevel(&podObject.Spec, security)
+
+		if len(ports) > 0 {
+			if serviceType == v1.ServiceTypeNodePort {
+				podObject.Labels[tools.KUFAST_EXPOSED_LABEL] = "true"
+			} else if len(composeService.Ports) > 0 {
+				warnings = append(warnings, prefix+".ports: Published ports are only reachable within your tenant. "+
+					"Use --type nodeport to reach them from outside.")
+			}
+			services = append(services, objectFactory.NewService(name, namespaceName, workloadName, serviceType, ports))
+		}
+
+		restart := strings.ToLower(composeService.Restart)
+		if composeService.Deploy.Replicas != nil || restart == "always" || restart == "unless-stopped" {
+			replicas := int32(1)
+			if composeService.Deploy.Replicas != nil {
+				replicas = *composeService.Deploy.Replicas
+			}
+			deployments = append(deployments, objectFactory.NewDeployment(workloadName, namespaceName, replicas, podObject))
+		} else {
+			pods = append(pods, podObject)
+		}
+	}
+
+	//Collect the objects to be created
+	var created []string
+	for _, secret := range secrets {
+		created = append(created, "secret "+secret.Name)
+	}
+	for _, service := range services {
+		created = append(created, "service "+service.Name)
+	}
+	for _, deployment := range deployments {
+		created = append(created, "deployment "+deployment.Name)
+	}
+	for _, pod := range pods {
+		created = append(created, "pod "+pod.Name)
+	}
+	if dryRun {
+		return created, warnings, nil
+	}
+
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, warnings, err
+	}
+
+	//Create all objects. Secrets first, as the workloads depend on them.
+	var done []string
+	for _, secret := range secrets {
+		_, err = clientset.CoreV1().Secrets(namespaceName).Create(context.TODO(), secret, metav1.CreateOptions{})
+		if err != nil {
+			return done, warnings, err
+		}
+		done = append(done, "secret "+secret.Name)
+	}
+	for _, service := range services {
+		_, err = clientset.CoreV1().Services(namespaceName).Create(context.TODO(), service, metav1.CreateOptions{})
+		if err != nil {
+			return done, warnings, err
+		}
+		done = append(done, "service "+service.Name)
+	}
+	for _, deployment := range deployments {
+		_, err = clientset.AppsV1().Deployments(namespaceName).Create(context.TODO(), deployment, metav1.CreateOptions{})
+		if err != nil {
+			return done, warnings, err
+		}
+		done = append(done, "deployment "+deployment.Name)
+	}
+	for _, pod := range pods {
+		_, historyErr := addPodRevision(pod, []tools.PodRevision{})
+		if historyErr != nil {
+			warnings = append(warnings, "services."+pod.Name+": The revision history could not be recorded: "+historyErr.Error())
+		}
+		_, err = clientset.CoreV1().Pods(namespaceName).Create(context.TODO(), pod, metav1.CreateOptions{})
+		if err != nil {
+			return done, warnings, err
+		}
+		done = append(done, "pod "+pod.Name)
+	}
+
+	return done, warnings, nil
+}
+
+// newPodFromComposeService creates a pod object from a service of a docker-compose file. Resources not set in the
+// compose file are drawn from the cpu, memory and storage flags. Besides the pod, the ports of the service and the
+// parts of the service that could not be imported are returned.
+func newPodFromComposeService(cmd *cobra.Command, podName string, namespaceName string, composeDir string,
+	composeService tools.ComposeService) (*v1.Pod, []int32, []string, error) {
+	var warnings []string
+
+	cpu, _ := cmd.Flags().GetString("cpu")
+	ram, _ := cmd.Flags().GetString("memory")
+	storage, _ := cmd.Flags().GetString("storage")
+	deploySecret, _ := cmd.Flags().GetString("deploy-secret")
+	limits := composeService.Deploy.Resources.Limits
+	if limits.Cpus != "" {
+		cpu = string(limits.Cpus)
+	} else if composeService.Cpus != "" {
+		cpu = string(composeService.Cpus)
+	}
+	if limits.Memory != "" {
+		ram = tools.ConvertComposeMemory(string(limits.Memory))
+	} else if composeService.MemLimit != "" {
+		ram = tools.ConvertComposeMemory(string(composeService.MemLimit))
+	}
+
+	var restartPolicy v1.RestartPolicy
+	switch strings.ToLower(strings.Split(composeService.Restart, ":")[0]) {
+	case "", "no":
+		restartPolicy = v1.RestartPolicyNever
+	case "on-failure":
+		restartPolicy = v1.RestartPolicyOnFailure
+		if strings.Contains(composeService.Restart, ":") {
+			warnings = append(warnings, "restart: The maximum number of retries is not supported.")
+		}
+	case "always", "unless-stopped":
+		restartPolicy = v1.RestartPolicyAlways
+	default:
+		return nil, nil, nil, errors.New(composeService.Restart + ": Unknown restart policy.")
+	}
+	if composeService.Deploy.Replicas != nil && restartPolicy != v1.RestartPolicyAlways {
+		warnings = append(warnings, "restart: Services with replicas are always restarted.")
+	}
+
+	ports, portWarnings, err := composeServicePorts(composeService)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	warnings = append(warnings, portWarnings...)
+
+	var secrets []string
+	for _, entry := range composeService.Secrets {
+		secret, err := tools.ParseComposeSecretReference(entry)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		secrets = append(secrets, tools.ConvertComposeName(secret))
+		warnings = append(warnings, "secrets: Secret "+secret+" is provided as environment variable "+
+			tools.ConvertComposeName(secret)+" instead of the file /run/secrets/"+secret+".")
+	}
+
+	podObject := objectFactory.NewPod(podName, composeService.Image, namespaceName, secrets, deploySecret, cpu, ram, storage,
+		restartPolicy, ports, composeService.Entrypoint)
+	container := &podObject.Spec.Containers[0]
+	container.Args = composeService.Command
+	container.WorkingDir = composeService.WorkingDir
+
+	reservations := composeService.Deploy.Resources.Reservations
+	reservedRam := string(composeService.MemReservation)
+	if reservations.Memory != "" {
+		reservedRam = string(reservations.Memory)
+	}
+	if reservedRam != "" {
+		reservedRam = tools.ConvertComposeMemory(reservedRam)
+	}
+	objectFactory.SetContainerRequests(container, string(reservations.Cpus), reservedRam, "")
+
+	if composeService.User != "" {
+		user, err := strconv.ParseInt(strings.Split(composeService.User, ":")[0], 10, 64)
+		if err != nil {
+			warnings = append(warnings, "user: Only numeric user IDs are supported.")
+		} else {
+			objectFactory.SetRunAsUser(container, user)
+		}
+		if strings.Contains(composeService.User, ":") {
+			warnings = append(warnings, "user: Groups are not supported.")
+		}
+	}
+
+	//Variables of env files are overridden by the environment of the service
+	for _, envFile := range composeService.EnvFile {
+		variables, err := tools.ReadEnvFile(composePath(composeDir, envFile))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for _, variable := range variables {
+			key, value, _ := strings.Cut(variable, "=")
+			objectFactory.SetEnvVar(container, key, value)
+		}
+	}
+	for _, key := range sortedKeys(composeService.Environment) {
+		objectFactory.SetEnvVar(container, key, composeService.Environment[key])
+	}
+
+	return podObject, ports, warnings, nil
+}
+
+// composeServicePorts returns the container ports of a compose service from its ports and expose keys. Each port is
+// only returned once, as the names of service ports have to be unique. Published ports differing from the container
+// port are not supported and returned as warnings.
+func composeServicePorts(composeService tools.ComposeService) ([]int32, []string, error) {
+	var warnings []string
+	var ports []int32
+	addPort := func(port int32) {
+		for _, existing := range ports {
+			if existing == port {
+				return
+			}
+		}
+		ports = append(ports, port)
+	}
+	for _, entry := range composeService.Ports {
+		port, published, err := tools.ParseComposePort(entry)
+		if err != nil {
+			return nil, nil, err
+		}
+		if published != "" && published != strconv.Itoa(int(port)) {
+			warnings = append(warnings, "ports: Port "+published+" is not supported, the service uses the container port "+
+				strconv.Itoa(int(port))+" instead.")
+		}
+		addPort(port)
+	}
+	for _, entry := range composeService.Expose {
+		port, err := strconv.ParseInt(strings.Split(string(entry), "/")[0], 10, 32)
+		if err != nil {
+			return nil, nil, errors.New(string(entry) + ": Port ranges and invalid ports are not supported.")
+		}
+		addPort(int32(port))
+	}
+
+	return ports, warnings, nil
+}
+
+// composePath resolves a path of a docker-compose file, which is relative to the directory of the compose file.
+func composePath(composeDir string, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(composeDir, path)
+}
+
+// sortedKeys returns the keys of a map in alphabetical order.
+func sortedKeys[K ~string, T any](values map[K]T) []K {
+	var keys []K
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i] < keys[j]
+	})
+	return keys
+}
@@ -0,0 +1,71 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	"encoding/json"
+	"kufast/tools"
+	"reflect"
+	"testing"
+)
+
+func TestComposeServicePorts(t *testing.T) {
+	tests := []struct {
+		name         string
+		ports        []string
+		expose       []tools.ComposeScalar
+		wantPorts    []int32
+		wantWarnings int
+		wantErr      bool
+	}{
+		{"container ports", []string{`80`, `"443"`}, nil, []int32{80, 443}, 0, false},
+		{"same published port", []string{`"8080:8080"`}, nil, []int32{8080}, 0, false},
+		{"differing published port", []string{`"8080:80"`}, nil, []int32{80}, 1, false},
+		{"long syntax", []string{`{"target": 80, "published": "8080"}`}, nil, []int32{80}, 1, false},
+		{"port in ports and expose", []string{`"80"`}, []tools.ComposeScalar{"80", "9000/tcp"}, []int32{80, 9000}, 0, false},
+		{"port published twice", []string{`"8080:80"`, `"8081:80"`}, nil, []int32{80}, 2, false},
+		{"port range", []string{`"3000-3005"`}, nil, nil, 0, true},
+		{"invalid expose", nil, []tools.ComposeScalar{"9000-9001"}, nil, 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			composeService := tools.ComposeService{Expose: test.expose}
+			for _, port := range test.ports {
+				composeService.Ports = append(composeService.Ports, json.RawMessage(port))
+			}
+
+			ports, warnings, err := composeServicePorts(composeService)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("composeServicePorts() error = %v, want error %v", err, test.wantErr)
+			}
+			if !reflect.DeepEqual(ports, test.wantPorts) {
+				t.Errorf("composeServicePorts() ports = %v, want %v", ports, test.wantPorts)
+			}
+			if len(warnings) != test.wantWarnings {
+				t.Errorf("composeServicePorts() warnings = %v, want %d", warnings, test.wantWarnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	"context"
+	"errors"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kufast/objectFactory"
+	"kufast/tools"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CreateConfigMap creates a new configmap from literals and files. All parameters are drawn from the cobra command.
+func CreateConfigMap(configMapName string, cmd *cobra.Command) error {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	data, err := readDataFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	configMapObject := objectFactory.NewConfigMap(namespaceName, configMapName, data)
+
+	_, err = clientset.CoreV1().ConfigMaps(namespaceName).Create(context.TODO(), configMapObject, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// readDataFromCmd reads the data of a configmap or secret from the from-literal and from-file flags. Literals are given
+// as KEY=VALUE, files either as path or as KEY=path. Without a key, the name of the file is used as key.
+func readDataFromCmd(cmd *cobra.Command) (map[string]string, error) {
+	literals, _ := cmd.Flags().GetStringArray("from-literal")
+	files, _ := cmd.Flags().GetStringArray("from-file")
+
+	data := map[string]string{}
+	for _, literal := range literals {
+		key, value, err := tools.ParseKeyValue(literal)
+		if err != nil {
+			return nil, err
+		}
+		data[key] = value
+	}
+	for _, file := range files {
+		key, path, found := strings.Cut(file, "=")
+		if !found {
+			key, path = filepath.Base(file), file
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		data[key] = string(content)
+	}
+
+	if len(data) == 0 {
+		return nil, errors.New(tools.ERROR_NO_DATA)
+	}
+	return data, nil
+}
+
+// GetConfigMap gets an existing configmap. All parameters are drawn from the cobra command.
+func GetConfigMap(configMapName string, cmd *cobra.Command) (*v1.ConfigMap, error) {
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	configMap, err := clientset.CoreV1().ConfigMaps(namespaceName).Get(context.TODO(), configMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return configMap, nil
+}
+
+// ListConfigMaps lists all configmaps of a tenant created with kufast. The configmaps holding the shared pod profiles
+// are left out, as they are managed with the profile commands. All parameters are drawn from the cobra command.
+func ListConfigMaps(cmd *cobra.Command) ([]v1.ConfigMap, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantName, err := GetTenantNameFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := ListTargetsFromCmd(cmd, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []v1.ConfigMap
+	for _, target := range targets {
+		list, err := clientset.CoreV1().ConfigMaps(tenantName+"-"+target.Name).List(context.TODO(),
+			metav1.ListOptions{LabelSelector: tools.KUFAST_TENANT_LABEL + ",!" + tools.KUFAST_PROFILES_LABEL})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, list.Items...)
+	}
+
+	return results, nil
+}
+
+// DeleteConfigMap deletes an existing configmap as an async function. The input channel is closed, as soon as the
+// operation completes. All parameters are drawn from the cobra command.
+func DeleteConfigMap(configMapName string, cmd *cobra.Command) <-chan string {
+	res := make(chan string)
+
+	go func() {
+		defer close(res)
+
+		clientset, _, err := tools.GetUserClient(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		err = clientset.CoreV1().ConfigMaps(namespaceName).Delete(context.TODO(), configMapName, metav1.DeleteOptions{})
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		//Check for the configmap been deleted from the system
+		timeout := 80
+		for timeout > 0 {
+			timeout--
+			time.Sleep(time.Millisecond * 250)
+			_, err := clientset.CoreV1().ConfigMaps(namespaceName).Get(context.TODO(), configMapName, metav1.GetOptions{})
+			if err != nil {
+				res <- ""
+				return
+			}
+		}
+		res <- "Operation timeout. Your configmap still exists. Please look after it with 'kufast list configmaps'"
+	}()
+
+	return res
+}
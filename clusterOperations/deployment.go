@@ -0,0 +1,273 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kufast/objectFactory"
+	"kufast/tools"
+	"time"
+)
+
+// CreateDeployment creates a new deployment within a tenant-target. The pod template is built from the same flags as
+// a pod. All parameters are drawn from the environment on the command line.
+func CreateDeployment(deploymentName string, imageName string, cmd *cobra.Command) error {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	replicas, _ := cmd.Flags().GetInt32("replicas")
+	if replicas < 0 {
+		return errors.New(tools.ERROR_INVALID_REPLICAS)
+	}
+
+	target, _ := cmd.Flags().GetString("target")
+	if target != "" && !IsValidTarget(cmd, target, false) {
+		return errors.New("Invalid target for tenant")
+	}
+
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	podObject, err := newPodFromCmd(cmd, deploymentName, imageName, namespaceName)
+	if err != nil {
+		return err
+	}
+
+	deploymentObject := objectFactory.NewDeployment(deploymentName, namespaceName, replicas, podObject)
+
+	_, err = clientset.AppsV1().Deployments(namespaceName).Create(context.TODO(), deploymentObject, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateDeployment changes the pod template and the number of replicas of an existing deployment. Only flags that
+// have been set explicitly are applied. Changes of the pod template trigger a rolling update.
+// All parameters are drawn from the environment on the command line.
+func UpdateDeployment(deploymentName string, cmd *cobra.Command) error {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	deployment, err := clientset.AppsV1().Deployments(namespaceName).Get(context.TODO(), deploymentName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed("replicas") {
+		replicas, _ := cmd.Flags().GetInt32("replicas")
+		if replicas < 0 {
+			return errors.New(tools.ERROR_INVALID_REPLICAS)
+		}
+		deployment.Spec.Replicas = &replicas
+	}
+	err = applyPodFlagsToSpec(cmd, &deployment.Spec.Template.Spec)
+	if err != nil {
+		return err
+	}
+
+	_, err = clientset.AppsV1().Deployments(namespaceName).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// WaitForDeploymentRollout waits until all replicas of a deployment are updated and available. The progress function
+// is called with a short status message whenever the rollout state changes. Returns an error, if the rollout does not
+// complete in time. All parameters are drawn from the environment on the command line.
+func WaitForDeploymentRollout(deploymentName string, cmd *cobra.Command, progress func(string)) error {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	lastStatus := ""
+	timeout := 300
+	for timeout > 0 {
+		timeout--
+
+		deployment, err := clientset.AppsV1().Deployments(namespaceName).Get(context.TODO(), deploymentName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		for _, condition := range deployment.Status.Conditions {
+			if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+				return errors.New("Rollout of deployment " + deploymentName + " failed: " + condition.Message)
+			}
+		}
+
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+
+		status := fmt.Sprintf(" %d of %d replicas updated, %d available", deployment.Status.UpdatedReplicas, replicas,
+			deployment.Status.AvailableReplicas)
+		if status != lastStatus {
+			progress(status)
+			lastStatus = status
+		}
+
+		if IsDeploymentRolledOut(deployment) {
+			return nil
+		}
+
+		time.Sleep(time.Millisecond * 1000)
+	}
+
+	return errors.New("Operation timeout. Your deployment is still rolling out. Please look after it with 'kufast get deployment'")
+}
+
+// IsDeploymentRolledOut checks, if the latest pod template of a deployment is running on all replicas and no old
+// replicas are left.
+func IsDeploymentRolledOut(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas &&
+		deployment.Status.Replicas == replicas
+}
+
+// GetDeployment returns a deployment from a string. All parameters are drawn from the environment on the command line.
+func GetDeployment(deploymentName string, cmd *cobra.Command) (*appsv1.Deployment, error) {
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := clientset.AppsV1().Deployments(namespaceName).Get(context.TODO(), deploymentName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return deployment, nil
+}
+
+// ListTenantDeployments lists all deployments in all tenant-targets of a tenant.
+func ListTenantDeployments(cmd *cobra.Command) ([]appsv1.Deployment, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := ListTargetsFromCmd(cmd, false)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantName, err := GetTenantNameFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []appsv1.Deployment
+	for _, target := range targets {
+		list, err := clientset.AppsV1().Deployments(tenantName+"-"+target.Name).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, list.Items...)
+	}
+
+	return results, nil
+}
+
+// DeleteDeployment deletes an existent deployment together with its pods as an async function. The input channel is
+// closed, as soon as the operation completes. All parameters are drawn from the environment on the command line.
+func DeleteDeployment(cmd *cobra.Command, deploymentName string) <-chan string {
+	res := make(chan string)
+
+	go func() {
+		defer close(res)
+		clientset, _, err := tools.GetUserClient(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		propagation := metav1.DeletePropagationForeground
+		err = clientset.AppsV1().Deployments(namespaceName).Delete(context.TODO(), deploymentName,
+			metav1.DeleteOptions{PropagationPolicy: &propagation})
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		//Check for the deployment been deleted from the system
+		timeout := 240
+		for timeout > 0 {
+			timeout--
+			time.Sleep(time.Millisecond * 250)
+			_, err := clientset.AppsV1().Deployments(namespaceName).Get(context.TODO(), deploymentName, metav1.GetOptions{})
+			if err != nil {
+				res <- ""
+				return
+			}
+		}
+		res <- "Operation timeout. Your deployment still exists. Please look after it with 'kufast get deployment'"
+	}()
+
+	return res
+}
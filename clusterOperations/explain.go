@@ -0,0 +1,450 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	"context"
+	"fmt"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"kufast/tools"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ExplainPod inspects a pod, its events, the quota and limit range of its tenant-target and the nodes of its target
+// and returns a plain-language diagnosis, one finding per entry. Information, that cannot be read with the credentials
+// of a tenant (e.g. the capacity of the nodes), is left out.
+func ExplainPod(podName string, cmd *cobra.Command) ([]string, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	pod, err := clientset.CoreV1().Pods(namespaceName).Get(context.TODO(), podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	targetDescription := "target " + tools.GetTargetFromNamespace(namespaceName)
+	target, err := GetTargetFromTargetName(cmd, tools.GetTargetFromNamespace(namespaceName), tools.GetTenantFromNamespace(namespaceName), false)
+	if err == nil {
+		targetDescription = "target " + target.AccessType + " " + target.Name
+	}
+
+	//The node selector of the tenant-target may point to the nodes of another target, see 'kufast update tenant-target'
+	nodeSelector := labels.Set(pod.Spec.NodeSelector)
+	namespace, err := clientset.CoreV1().Namespaces().Get(context.TODO(), namespaceName, metav1.GetOptions{})
+	if err == nil {
+		nodeSelector = podNodeSelector(pod, namespace)
+		selectorTarget, err := GetTargetFromTenantTarget(namespace)
+		if err == nil {
+			targetDescription = "target " + selectorTarget.AccessType + " " + selectorTarget.Name
+		}
+	}
+
+	events, err := clientset.CoreV1().Events(namespaceName).List(context.TODO(), metav1.ListOptions{
+		FieldSelector: fields.Set{"involvedObject.kind": "Pod", "involvedObject.name": podName}.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	diagnosis := explainPodStatus(pod)
+
+	//Only the latest warning of each reason is relevant
+	latestWarnings := map[string]v1.Event{}
+	for _, event := range events.Items {
+		if event.Type != v1.EventTypeWarning {
+			continue
+		}
+		latest, exists := latestWarnings[event.Reason]
+		if !exists || latest.LastTimestamp.Before(&event.LastTimestamp) {
+			latestWarnings[event.Reason] = event
+		}
+	}
+	for _, reason := range sortedKeys(latestWarnings) {
+		event := latestWarnings[reason]
+		switch reason {
+		case "FailedScheduling":
+			if pod.Spec.NodeName == "" {
+				nodes := getTargetNodeCapacities(clientset, nodeSelector)
+				diagnosis = append(diagnosis, explainSchedulingFailure(event.Message, pod, targetDescription, nodes)...)
+			}
+		case "FailedMount", "FailedAttachVolume":
+			diagnosis = append(diagnosis, "A volume of the pod cannot be mounted: "+event.Message)
+		case "Unhealthy":
+			diagnosis = append(diagnosis, "A probe of the pod fails: "+event.Message)
+		case "BackOff", "Failed":
+			//Covered by the states of the containers
+		default:
+			diagnosis = append(diagnosis, reason+": "+event.Message)
+		}
+	}
+
+	quotaDiagnosis, err := explainQuota(clientset, namespaceName)
+	if err != nil {
+		return nil, err
+	}
+	diagnosis = append(diagnosis, quotaDiagnosis...)
+
+	limitRangeDiagnosis, err := explainLimitRange(clientset, pod)
+	if err != nil {
+		return nil, err
+	}
+	diagnosis = append(diagnosis, limitRangeDiagnosis...)
+
+	return diagnosis, nil
+}
+
+// explainPodStatus describes the phase of a pod and the problems of its containers.
+func explainPodStatus(pod *v1.Pod) []string {
+	var diagnosis []string
+
+	switch pod.Status.Phase {
+	case v1.PodPending:
+		if pod.Spec.NodeName == "" {
+			diagnosis = append(diagnosis, "The pod has not been assigned to a node yet.")
+		} else {
+			diagnosis = append(diagnosis, "The pod has been assigned to node "+pod.Spec.NodeName+", but its containers have not started yet.")
+		}
+	case v1.PodRunning:
+		if isPodReady(pod) {
+			diagnosis = append(diagnosis, "The pod is running and ready on node "+pod.Spec.NodeName+".")
+		} else {
+			diagnosis = append(diagnosis, "The pod is running on node "+pod.Spec.NodeName+", but not all of its containers are ready.")
+		}
+	case v1.PodSucceeded:
+		diagnosis = append(diagnosis, "The pod has completed successfully.")
+	case v1.PodFailed:
+		diagnosis = append(diagnosis, strings.TrimSpace("The pod has failed. "+pod.Status.Message))
+	}
+
+	containers := append(pod.Spec.InitContainers, pod.Spec.Containers...)
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		var container v1.Container
+		for _, c := range containers {
+			if c.Name == status.Name {
+				container = c
+			}
+		}
+
+		if waiting := status.State.Waiting; waiting != nil {
+			switch waiting.Reason {
+			case "ErrImagePull", "ImagePullBackOff":
+				diagnosis = append(diagnosis, "The image "+container.Image+" of container "+status.Name+" cannot be pulled. "+
+					"Please check the name and tag of the image and the deploy secret for private registries. "+waiting.Message)
+			case "InvalidImageName":
+				diagnosis = append(diagnosis, "The image name "+container.Image+" of container "+status.Name+" is invalid.")
+			case "CreateContainerConfigError":
+				if strings.Contains(waiting.Message, "runAsNonRoot") {
+					diagnosis = append(diagnosis, tools.CreateImageRunsAsRootError(status.Name).Error())
+				} else {
+					diagnosis = append(diagnosis, "The configuration of container "+status.Name+" is invalid, e.g. a secret or configmap "+
+						"it uses does not exist: "+waiting.Message)
+				}
+			case "CrashLoopBackOff":
+				diagnosis = append(diagnosis, "Container "+status.Name+" crashes repeatedly and has been restarted "+
+					strconv.Itoa(int(status.RestartCount))+" times.")
+			case "CreateContainerError", "RunContainerError":
+				diagnosis = append(diagnosis, "Container "+status.Name+" cannot be started: "+waiting.Message)
+			}
+		}
+
+		terminated := status.State.Terminated
+		if terminated == nil {
+			terminated = status.LastTerminationState.Terminated
+		}
+		if terminated != nil && terminated.Reason == "OOMKilled" {
+			diagnosis = append(diagnosis, "Container "+status.Name+" has been killed, as it exceeded its memory limit of "+
+				container.Resources.Limits.Memory().String()+". Please raise the memory limit of the pod.")
+		} else if terminated != nil && terminated.ExitCode != 0 {
+			diagnosis = append(diagnosis, "Container "+status.Name+" exited with code "+strconv.Itoa(int(terminated.ExitCode))+
+				". Please check its log with 'kufast get logs "+pod.Name+" --container "+status.Name+"'.")
+		}
+
+		if status.State.Running != nil && !status.Ready && container.ReadinessProbe != nil {
+			diagnosis = append(diagnosis, "Container "+status.Name+" is running, but its readiness probe does not succeed yet.")
+		}
+	}
+
+	return diagnosis
+}
+
+// podNodeSelector returns the node selector of a pod merged with the node selector annotation of its namespace, as the
+// PodNodeSelector admission plugin does. An invalid annotation is ignored.
+func podNodeSelector(pod *v1.Pod, namespace *v1.Namespace) labels.Set {
+	nodeSelector := labels.Set{}
+	for key, value := range pod.Spec.NodeSelector {
+		nodeSelector[key] = value
+	}
+	namespaceSelector, err := labels.ConvertSelectorToLabelsMap(namespace.Annotations[tools.KUFAST_NODE_SELECTOR_ANNOTATION])
+	if err == nil {
+		for key, value := range namespaceSelector {
+			nodeSelector[key] = value
+		}
+	}
+	return nodeSelector
+}
+
+// nodeCapacity holds the resources of a node, that are not requested by any pod.
+type nodeCapacity struct {
+	name string
+	free v1.ResourceList
+}
+
+// getTargetNodeCapacities returns the unrequested resources of the nodes matching the node selector of a pod and its
+// tenant-target. As tenants cannot read nodes, nil is returned, if the nodes cannot be inspected.
+func getTargetNodeCapacities(clientset *kubernetes.Clientset, nodeSelector labels.Set) []nodeCapacity {
+	nodes, err := clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(nodeSelector).String(),
+	})
+	if err != nil {
+		return nil
+	}
+
+	var capacities []nodeCapacity
+	for _, node := range nodes.Items {
+		pods, err := clientset.CoreV1().Pods("").List(context.TODO(), metav1.ListOptions{
+			FieldSelector: "spec.nodeName=" + node.Name + ",status.phase!=Succeeded,status.phase!=Failed",
+		})
+		if err != nil {
+			return nil
+		}
+
+		free := v1.ResourceList{}
+		for name, allocatable := range node.Status.Allocatable {
+			quantity := allocatable.DeepCopy()
+			for _, nodePod := range pods.Items {
+				request := podRequest(&nodePod, name)
+				quantity.Sub(request)
+			}
+			free[name] = quantity
+		}
+		capacities = append(capacities, nodeCapacity{name: node.Name, free: free})
+	}
+	return capacities
+}
+
+// podRequest returns the amount of a resource requested by a pod, as the scheduler calculates it. Init containers run
+// one after another before the other containers, so only the largest request of them counts. The overhead of the
+// runtime class of the pod is added.
+func podRequest(pod *v1.Pod, name v1.ResourceName) resource.Quantity {
+	request := resource.Quantity{}
+	for _, container := range pod.Spec.Containers {
+		request.Add(container.Resources.Requests[name])
+	}
+	for _, container := range pod.Spec.InitContainers {
+		if initRequest := container.Resources.Requests[name]; initRequest.Cmp(request) > 0 {
+			request = initRequest.DeepCopy()
+		}
+	}
+	request.Add(pod.Spec.Overhead[name])
+	return request
+}
+
+// schedulingReason matches a single reason of a scheduling failure, e.g. "2 Insufficient cpu".
+var schedulingReason = regexp.MustCompile(`^(\d+) (.*)$`)
+
+// explainSchedulingFailure translates the message of a failed scheduling, e.g. "0/3 nodes are available: 1 Insufficient
+// cpu, 2 node(s) didn't match Pod's node affinity/selector.", into findings. Nodes outside the target are left out.
+func explainSchedulingFailure(message string, pod *v1.Pod, targetDescription string, nodes []nodeCapacity) []string {
+	reasons := message
+	if index := strings.Index(reasons, ". preemption:"); index >= 0 {
+		reasons = reasons[:index]
+	}
+	index := strings.Index(reasons, "available: ")
+	if index < 0 {
+		return []string{"The pod cannot be scheduled: " + message}
+	}
+	total := strings.TrimPrefix(strings.Fields(reasons)[0], "0/")
+	reasons = strings.TrimSuffix(reasons[index+len("available: "):], ".")
+
+	var diagnosis []string
+	for _, reason := range strings.Split(reasons, ", ") {
+		match := schedulingReason.FindStringSubmatch(reason)
+		if match == nil {
+			diagnosis = append(diagnosis, "The pod cannot be scheduled: "+reason)
+			continue
+		}
+		count, text := match[1], match[2]
+
+		switch {
+		case strings.HasPrefix(text, "Insufficient "):
+			name := v1.ResourceName(strings.TrimPrefix(text, "Insufficient "))
+			finding := "No node in " + targetDescription + " has " + formatResourceQuantity(name, podRequest(pod, name)) + " free."
+			var mostFree *nodeCapacity
+			for i, node := range nodes {
+				if free, exists := node.free[name]; exists && (mostFree == nil || free.Cmp(mostFree.free[name]) > 0) {
+					mostFree = &nodes[i]
+				}
+			}
+			if mostFree != nil {
+				finding += " Node " + mostFree.name + " has the most with " + formatResourceQuantity(name, mostFree.free[name]) + " free."
+			}
+			diagnosis = append(diagnosis, finding+" Please lower the requests of the pod or remove other workloads from the target.")
+		case strings.Contains(text, "didn't match Pod's node affinity/selector"):
+			if count == total {
+				diagnosis = append(diagnosis, "No node belongs to "+targetDescription+". Please contact your administrator.")
+			}
+		case strings.Contains(text, "node.kubernetes.io/unschedulable"), strings.Contains(text, "were unschedulable"):
+			diagnosis = append(diagnosis, count+" node(s) are in maintenance and do not accept new pods.")
+		case strings.Contains(text, "taint"):
+			taint := text
+			if start := strings.Index(text, "{"); start >= 0 {
+				taint = strings.Trim(text[start:], "{}")
+			}
+			diagnosis = append(diagnosis, count+" node(s) have the taint "+taint+", which the pod does not tolerate.")
+		case strings.Contains(text, "topology spread constraints"), strings.Contains(text, "affinity rules"):
+			diagnosis = append(diagnosis, count+" node(s) have been excluded by the placement policy of the tenant-target.")
+		case strings.Contains(text, "PersistentVolumeClaim"), strings.Contains(text, "volume node affinity conflict"):
+			diagnosis = append(diagnosis, "A persistent volume of the pod is not bound yet or is located on another node: "+text)
+		case strings.Contains(text, "Too many pods"):
+			diagnosis = append(diagnosis, count+" node(s) have reached their maximum number of pods.")
+		default:
+			diagnosis = append(diagnosis, count+" node(s): "+text)
+		}
+	}
+	return diagnosis
+}
+
+// quotaDescriptions are the plain-language names of the resources limited by the quota of a tenant-target.
+var quotaDescriptions = map[v1.ResourceName]string{
+	"limits.cpu":                 "CPU",
+	"requests.cpu":               "CPU requests",
+	"limits.memory":              "memory",
+	"requests.memory":            "memory requests",
+	"limits.ephemeral-storage":   "storage",
+	"requests.ephemeral-storage": "storage requests",
+	"requests.storage":           "volume storage",
+	"persistentvolumeclaims":     "volumes",
+	"services.nodeports":         "NodePort services",
+}
+
+// explainQuota reports the resources of the quota of a tenant-target, that are exhausted or nearly exhausted.
+func explainQuota(clientset *kubernetes.Clientset, namespaceName string) ([]string, error) {
+	quotas, err := clientset.CoreV1().ResourceQuotas(namespaceName).List(context.TODO(), metav1.ListOptions{})
+	if apierrors.IsForbidden(err) {
+		return []string{"The quota of the tenant-target cannot be read with your credentials. " +
+			"Please ask your administrator to update the tenant-target with 'kufast update tenant-target'."}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var diagnosis []string
+	for _, quota := range quotas.Items {
+		for _, name := range sortedKeys(quota.Spec.Hard) {
+			hard := quota.Spec.Hard[name]
+			used := quota.Status.Used[name]
+			if hard.IsZero() {
+				continue
+			}
+
+			description := quotaDescriptions[name]
+			if description == "" {
+				description = string(name)
+			}
+			ratio := used.AsApproximateFloat64() / hard.AsApproximateFloat64()
+			if ratio >= 1 {
+				diagnosis = append(diagnosis, "Quota "+description+" exhausted: "+used.String()+" of "+hard.String()+" used.")
+			} else if ratio >= 0.8 {
+				diagnosis = append(diagnosis, "Quota "+description+" nearly exhausted: "+used.String()+" of "+hard.String()+" used.")
+			}
+		}
+	}
+	return diagnosis, nil
+}
+
+// explainLimitRange reports the containers of a pod, that violate the limit range of their tenant-target. These pods
+// cannot be created again, e.g. by an update, until their limits have been fixed.
+func explainLimitRange(clientset *kubernetes.Clientset, pod *v1.Pod) ([]string, error) {
+	limitRanges, err := clientset.CoreV1().LimitRanges(pod.Namespace).List(context.TODO(), metav1.ListOptions{})
+	if apierrors.IsForbidden(err) {
+		//Already reported by explainQuota
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var diagnosis []string
+	for _, limitRange := range limitRanges.Items {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type != v1.LimitTypeContainer {
+				continue
+			}
+			for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+				for _, name := range sortedKeys(item.Max) {
+					limit, exists := container.Resources.Limits[name]
+					if maxLimit := item.Max[name]; exists && limit.Cmp(maxLimit) > 0 {
+						diagnosis = append(diagnosis, fmt.Sprintf("The %s limit of container %s (%s) exceeds the maximum of %s per container.",
+							name, container.Name, limit.String(), maxLimit.String()))
+					}
+				}
+				for _, name := range sortedKeys(item.Min) {
+					request, exists := container.Resources.Requests[name]
+					if minRequest := item.Min[name]; exists && request.Cmp(minRequest) < 0 {
+						diagnosis = append(diagnosis, fmt.Sprintf("The %s request of container %s (%s) is below the minimum of %s per container.",
+							name, container.Name, request.String(), minRequest.String()))
+					}
+				}
+				for _, name := range sortedKeys(item.MaxLimitRequestRatio) {
+					limit := container.Resources.Limits[name]
+					request := container.Resources.Requests[name]
+					maxRatio := item.MaxLimitRequestRatio[name]
+					if !request.IsZero() && limit.AsApproximateFloat64()/request.AsApproximateFloat64() > maxRatio.AsApproximateFloat64() {
+						diagnosis = append(diagnosis, fmt.Sprintf("The %s limit of container %s is more than %s times its request.",
+							name, container.Name, maxRatio.String()))
+					}
+				}
+			}
+		}
+	}
+	return diagnosis, nil
+}
+
+// formatResourceQuantity returns a quantity of a resource in a readable form, e.g. "2 CPUs" or "500Mi memory".
+func formatResourceQuantity(name v1.ResourceName, quantity resource.Quantity) string {
+	if name == v1.ResourceCPU {
+		if quantity.Cmp(resource.MustParse("1")) == 0 {
+			return "1 CPU"
+		}
+		return quantity.String() + " CPUs"
+	}
+	return quantity.String() + " " + string(name)
+}
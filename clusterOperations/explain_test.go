@@ -0,0 +1,65 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"kufast/tools"
+	"reflect"
+	"testing"
+)
+
+func TestPodNodeSelector(t *testing.T) {
+	tests := []struct {
+		name       string
+		pod        map[string]string
+		annotation string
+		want       labels.Set
+	}{
+		{"namespace only", nil, "kufast.group/edge=true", labels.Set{"kufast.group/edge": "true"}},
+		{"pod and namespace", map[string]string{"disktype": "ssd"}, "kubernetes.io/hostname=node-1",
+			labels.Set{"disktype": "ssd", "kubernetes.io/hostname": "node-1"}},
+		{"target overridden by the namespace", map[string]string{"kufast.group/edge": "true"}, "kubernetes.io/hostname=node-1",
+			labels.Set{"kufast.group/edge": "true", "kubernetes.io/hostname": "node-1"}},
+		{"conflicting pod selector", map[string]string{"kubernetes.io/hostname": "node-2"}, "kubernetes.io/hostname=node-1",
+			labels.Set{"kubernetes.io/hostname": "node-1"}},
+		{"no annotation", map[string]string{"disktype": "ssd"}, "", labels.Set{"disktype": "ssd"}},
+		{"invalid annotation", map[string]string{"disktype": "ssd"}, "hostname", labels.Set{"disktype": "ssd"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := &v1.Pod{Spec: v1.PodSpec{NodeSelector: test.pod}}
+			namespace := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{tools.KUFAST_NODE_SELECTOR_ANNOTATION: test.annotation},
+			}}
+
+			if got := podNodeSelector(pod, namespace); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("podNodeSelector() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kufast/objectFactory"
+	"kufast/tools"
+	"sort"
+	"time"
+)
+
+// GetPodHistory returns the revision history of a pod, ordered from the oldest to the latest revision.
+// All parameters are drawn from the environment on the command line.
+func GetPodHistory(podName string, cmd *cobra.Command) ([]tools.PodRevision, error) {
+	pod, err := GetPod(podName, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	return podRevisions(pod)
+}
+
+// RollbackPod recreates a pod from a revision of its revision history as an async function. With revision 0, the pod
+// is rolled back to the revision before the latest one. The rollback itself is recorded as a new revision. If the
+// revision cannot be recorded, a warning is sent after the result of the rollback. The input channel is closed, as
+// soon as the operation completes. All parameters are drawn from the environment on the command line.
+func RollbackPod(podName string, revision int, cmd *cobra.Command) <-chan string {
+	res := make(chan string, 2)
+
+	go func() {
+		defer close(res)
+		clientset, _, err := tools.GetUserClient(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		//Keep the current pod to restore it, if the revision does not start
+		pod, err := clientset.CoreV1().Pods(namespaceName).Get(context.TODO(), podName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			res <- "No revision to roll back to found, as pod " + podName + " does not exist anymore."
+			return
+		} else if err != nil {
+			res <- err.Error()
+			return
+		}
+		previousPod := objectFactory.NewPodFromPod(pod)
+
+		revisions, err := podRevisions(pod)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		var target *tools.PodRevision
+		if revision == 0 && len(revisions) > 1 {
+			target = &revisions[len(revisions)-2]
+		}
+		for i := range revisions {
+			if revisions[i].Revision == revision {
+				target = &revisions[i]
+			}
+		}
+		if target == nil {
+			res <- "No revision to roll back to found in the history of pod " + podName + ". Please look after it with 'kufast history pod'"
+			return
+		}
+
+		//The pod is usable without the revision history, so a failed recording is only reported
+		podObject := objectFactory.NewPodFromRevision(podName, namespaceName, *target)
+		_, historyErr := addPodRevision(podObject, revisions)
+
+		err = recreatePod(clientset, podObject)
+		if err == nil {
+			err = waitForPodStart(clientset, namespaceName, podName, func(string) {})
+		}
+		if err != nil {
+			restoreErr := recreatePod(clientset, previousPod)
+			if restoreErr != nil {
+				res <- "The rollback failed: " + err.Error() + " Restoring the previous pod failed as well: " + restoreErr.Error()
+				return
+			}
+			res <- "The rollback failed: " + err.Error() + " The previous pod has been restored."
+			return
+		}
+
+		res <- ""
+		if historyErr != nil {
+			res <- tools.CreateHistoryWarning(podName, historyErr)
+		}
+	}()
+
+	return res
+}
+
+// podRevisions returns all revisions stored in the revision history of a pod, ordered from the oldest to the latest
+// revision. An empty list is returned, if the pod has no history.
+func podRevisions(pod *v1.Pod) ([]tools.PodRevision, error) {
+	history, ok := pod.Annotations[tools.KUFAST_HISTORY_ANNOTATION]
+	if !ok {
+		return []tools.PodRevision{}, nil
+	}
+
+	var revisions []tools.PodRevision
+	err := json.Unmarshal([]byte(history), &revisions)
+	if err != nil {
+		return nil, errors.New("The revision history of pod " + pod.Name + " is invalid: " + err.Error())
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+
+	return revisions, nil
+}
+
+// addPodRevision adds the specification of a pod as new revision to the revisions and stores them in the annotation of
+// the pod, which holds its revision history. Only the latest revisions are kept, as far as they fit into the
+// annotation. The stored revisions are returned. The pod is not changed, if the revision cannot be added.
+func addPodRevision(pod *v1.Pod, revisions []tools.PodRevision) ([]tools.PodRevision, error) {
+	latest := 0
+	if len(revisions) > 0 {
+		latest = revisions[len(revisions)-1].Revision
+	}
+
+	//The node of the pod is chosen by the scheduler again, when the revision is applied
+	spec := pod.Spec.DeepCopy()
+	spec.NodeName = ""
+	history := append(append([]tools.PodRevision{}, revisions...), tools.PodRevision{
+		Revision: latest + 1,
+		Created:  time.Now(),
+		Labels:   pod.Labels,
+		Spec:     *spec,
+	})
+	if len(history) > tools.KUFAST_HISTORY_LIMIT {
+		history = history[len(history)-tools.KUFAST_HISTORY_LIMIT:]
+	}
+
+	//Remove the oldest revisions, until the history fits into the annotation
+	for len(history) > 0 {
+		data, err := json.Marshal(history)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) <= tools.KUFAST_HISTORY_MAX_SIZE {
+			annotations := map[string]string{}
+			for key, value := range pod.Annotations {
+				annotations[key] = value
+			}
+			annotations[tools.KUFAST_HISTORY_ANNOTATION] = string(data)
+			pod.Annotations = annotations
+			return history, nil
+		}
+		history = history[1:]
+	}
+
+	return nil, errors.New("The specification of the pod exceeds the maximum size of the revision history.")
+}
@@ -0,0 +1,125 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kufast/tools"
+	"strings"
+	"testing"
+)
+
+func TestAddPodRevision(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Annotations: map[string]string{"note": "kept"}},
+		Spec:       v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Name: "web", Image: "nginx:1.25"}}},
+	}
+	original := pod.Annotations
+
+	revisions := []tools.PodRevision{}
+	for i := 0; i < tools.KUFAST_HISTORY_LIMIT+2; i++ {
+		var err error
+		revisions, err = addPodRevision(pod, revisions)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(original) != 1 {
+		t.Errorf("annotations of the original pod changed: %v", original)
+	}
+	if pod.Annotations["note"] != "kept" {
+		t.Errorf("annotations = %v, want the other annotations kept", pod.Annotations)
+	}
+	stored, err := podRevisions(pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != tools.KUFAST_HISTORY_LIMIT {
+		t.Fatalf("stored %d revisions, want %d", len(stored), tools.KUFAST_HISTORY_LIMIT)
+	}
+	if stored[0].Revision != 3 || stored[len(stored)-1].Revision != tools.KUFAST_HISTORY_LIMIT+2 {
+		t.Errorf("revisions %d to %d stored, want 3 to %d", stored[0].Revision, stored[len(stored)-1].Revision, tools.KUFAST_HISTORY_LIMIT+2)
+	}
+	if stored[0].Spec.NodeName != "" || stored[0].Spec.Containers[0].Image != "nginx:1.25" {
+		t.Errorf("revision spec = %v, want the spec without the node", stored[0].Spec)
+	}
+}
+
+func TestAddPodRevisionSize(t *testing.T) {
+	large := strings.Repeat("x", tools.KUFAST_HISTORY_MAX_SIZE/3)
+	pod := &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "web", Args: []string{large}}}}}
+
+	revisions := []tools.PodRevision{}
+	for i := 0; i < 4; i++ {
+		var err error
+		revisions, err = addPodRevision(pod, revisions)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(pod.Annotations[tools.KUFAST_HISTORY_ANNOTATION]) > tools.KUFAST_HISTORY_MAX_SIZE {
+		t.Errorf("history of %d bytes stored, want at most %d", len(pod.Annotations[tools.KUFAST_HISTORY_ANNOTATION]), tools.KUFAST_HISTORY_MAX_SIZE)
+	}
+	if len(revisions) != 2 || revisions[1].Revision != 4 {
+		t.Errorf("%d revisions stored, want the latest 2", len(revisions))
+	}
+
+	tooLarge := &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "web", Args: []string{large + large + large}}}}}
+	_, err := addPodRevision(tooLarge, []tools.PodRevision{})
+	if err == nil || tooLarge.Annotations != nil {
+		t.Errorf("addPodRevision() error = %v, annotations %v, want an error and an unchanged pod", err, tooLarge.Annotations)
+	}
+}
+
+func TestPodRevisions(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []int
+		wantErr     bool
+	}{
+		{"no history", nil, []int{}, false},
+		{"sorted revisions", map[string]string{tools.KUFAST_HISTORY_ANNOTATION: `[{"revision":3},{"revision":1}]`}, []int{1, 3}, false},
+		{"invalid history", map[string]string{tools.KUFAST_HISTORY_ANNOTATION: `{"revision":`}, nil, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			revisions, err := podRevisions(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Annotations: test.annotations}})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("podRevisions() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if len(revisions) != len(test.want) {
+				t.Fatalf("podRevisions() = %v, want revisions %v", revisions, test.want)
+			}
+			for i, revision := range revisions {
+				if revision.Revision != test.want[i] {
+					t.Errorf("revision %d = %d, want %d", i, revision.Revision, test.want[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	"context"
+	"errors"
+	"github.com/spf13/cobra"
+	n1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kufast/objectFactory"
+	"kufast/tools"
+	"time"
+)
+
+// CreateIngress creates a new ingress, which routes a hostname and path to a service of the tenant-target. The
+// hostname must be within one of the domains the administrator allowed for the tenant, which the admission policy of
+// the tenant enforces for all ingresses.
+// All parameters are drawn from the environment on the command line.
+func CreateIngress(ingressName string, hostname string, cmd *cobra.Command) error {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	tenantName, err := GetTenantNameFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	serviceName, _ := cmd.Flags().GetString("service")
+	path, _ := cmd.Flags().GetString("path")
+	port, _ := cmd.Flags().GetInt32("port")
+	tlsSecret, _ := cmd.Flags().GetString("tls-secret")
+	ingressClass, _ := cmd.Flags().GetString("class")
+	if serviceName == "" {
+		serviceName = ingressName
+	}
+
+	//Ensure tenants can only use their own hostnames
+	domains, err := GetTenantDomains(cmd, tenantName)
+	if err != nil {
+		return err
+	}
+	if !tools.IsAllowedDomain(hostname, domains) {
+		return tools.CreateDomainNotAllowedError(hostname, domains)
+	}
+
+	service, err := clientset.CoreV1().Services(namespaceName).Get(context.TODO(), serviceName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if port == 0 {
+		if len(service.Spec.Ports) == 0 {
+			return errors.New(tools.ERROR_NO_PORTS)
+		}
+		port = service.Spec.Ports[0].Port
+	}
+
+	ingressObject := objectFactory.NewIngress(ingressName, namespaceName, hostname, path, serviceName, port, tlsSecret, ingressClass)
+
+	_, err = clientset.NetworkingV1().Ingresses(namespaceName).Create(context.TODO(), ingressObject, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListTenantIngresses lists all ingresses in all tenant-targets of a tenant.
+func ListTenantIngresses(cmd *cobra.Command) ([]n1.Ingress, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := ListTargetsFromCmd(cmd, false)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantName, err := GetTenantNameFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []n1.Ingress
+	for _, target := range targets {
+		list, err := clientset.NetworkingV1().Ingresses(tenantName+"-"+target.Name).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, list.Items...)
+	}
+
+	return results, nil
+}
+
+// DeleteIngress deletes an existent ingress as an async function. The input channel is closed, as soon as the
+// operation completes. All parameters are drawn from the environment on the command line.
+func DeleteIngress(cmd *cobra.Command, ingressName string) <-chan string {
+	res := make(chan string)
+
+	go func() {
+		defer close(res)
+		clientset, _, err := tools.GetUserClient(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		err = clientset.NetworkingV1().Ingresses(namespaceName).Delete(context.TODO(), ingressName, metav1.DeleteOptions{})
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		//Check for the ingress been deleted from the system
+		timeout := 80
+		for timeout > 0 {
+			timeout--
+			time.Sleep(time.Millisecond * 250)
+			_, err := clientset.NetworkingV1().Ingresses(namespaceName).Get(context.TODO(), ingressName, metav1.GetOptions{})
+			if err != nil {
+				res <- ""
+				return
+			}
+		}
+		res <- "Operation timeout. Your ingress still exists. Please look after it with 'kufast list ingresses'"
+	}()
+
+	return res
+}
@@ -0,0 +1,326 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	"context"
+	"errors"
+	"github.com/spf13/cobra"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kufast/objectFactory"
+	"kufast/tools"
+	"sort"
+	"time"
+)
+
+// CreateJob creates a new job within a tenant-target. The pod template is built from the same flags as a pod.
+// All parameters are drawn from the environment on the command line.
+func CreateJob(jobName string, imageName string, cmd *cobra.Command) error {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	jobObject, err := newJobFromCmd(cmd, jobName, imageName, namespaceName)
+	if err != nil {
+		return err
+	}
+
+	_, err = clientset.BatchV1().Jobs(namespaceName).Create(context.TODO(), jobObject, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateCronJob creates a new cronjob within a tenant-target, which starts a job according to its schedule. The job
+// template is built from the same flags as a job. All parameters are drawn from the environment on the command line.
+func CreateCronJob(cronJobName string, imageName string, cmd *cobra.Command) error {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	schedule, _ := cmd.Flags().GetString("schedule")
+	if schedule == "" {
+		return errors.New(tools.ERROR_MISSING_SCHEDULE)
+	}
+
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	jobObject, err := newJobFromCmd(cmd, cronJobName, imageName, namespaceName)
+	if err != nil {
+		return err
+	}
+
+	cronJobObject := objectFactory.NewCronJob(cronJobName, namespaceName, schedule, jobObject)
+
+	_, err = clientset.BatchV1().CronJobs(namespaceName).Create(context.TODO(), cronJobObject, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// newJobFromCmd creates a new job object from the job and pod flags on the command line.
+func newJobFromCmd(cmd *cobra.Command, jobName string, imageName string, namespaceName string) (*batchv1.Job, error) {
+	completions, _ := cmd.Flags().GetInt32("completions")
+	parallelism, _ := cmd.Flags().GetInt32("parallelism")
+	backoffLimit, _ := cmd.Flags().GetInt32("backoff-limit")
+	ttl, _ := cmd.Flags().GetInt32("ttl")
+	if completions < 1 || parallelism < 1 || backoffLimit < 0 {
+		return nil, errors.New(tools.ERROR_INVALID_JOB_SETTINGS)
+	}
+
+	target, _ := cmd.Flags().GetString("target")
+	if target != "" && !IsValidTarget(cmd, target, false) {
+		return nil, errors.New("Invalid target for tenant")
+	}
+
+	podObject, err := newPodFromCmd(cmd, jobName, imageName, namespaceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return objectFactory.NewJob(jobName, namespaceName, completions, parallelism, backoffLimit, ttl, podObject), nil
+}
+
+// GetJob returns a job from a string. All parameters are drawn from the environment on the command line.
+func GetJob(jobName string, cmd *cobra.Command) (*batchv1.Job, error) {
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := clientset.BatchV1().Jobs(namespaceName).Get(context.TODO(), jobName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// GetCronJob returns a cronjob from a string. All parameters are drawn from the environment on the command line.
+func GetCronJob(cronJobName string, cmd *cobra.Command) (*batchv1.CronJob, error) {
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	cronJob, err := clientset.BatchV1().CronJobs(namespaceName).Get(context.TODO(), cronJobName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return cronJob, nil
+}
+
+// ListTenantJobs lists all jobs in all tenant-targets of a tenant. This includes the jobs started by cronjobs.
+func ListTenantJobs(cmd *cobra.Command) ([]batchv1.Job, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := ListTargetsFromCmd(cmd, false)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantName, err := GetTenantNameFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []batchv1.Job
+	for _, target := range targets {
+		list, err := clientset.BatchV1().Jobs(tenantName+"-"+target.Name).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, list.Items...)
+	}
+
+	return results, nil
+}
+
+// ListTenantCronJobs lists all cronjobs in all tenant-targets of a tenant.
+func ListTenantCronJobs(cmd *cobra.Command) ([]batchv1.CronJob, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := ListTargetsFromCmd(cmd, false)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantName, err := GetTenantNameFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []batchv1.CronJob
+	for _, target := range targets {
+		list, err := clientset.BatchV1().CronJobs(tenantName+"-"+target.Name).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, list.Items...)
+	}
+
+	return results, nil
+}
+
+// GetCronJobRuns returns the jobs started by a cronjob from a list of jobs, ordered from the oldest to the latest run.
+func GetCronJobRuns(cronJob batchv1.CronJob, jobs []batchv1.Job) []batchv1.Job {
+	var runs []batchv1.Job
+	for _, job := range jobs {
+		if job.Namespace != cronJob.Namespace {
+			continue
+		}
+		for _, owner := range job.OwnerReferences {
+			if owner.Kind == "CronJob" && owner.Name == cronJob.Name {
+				runs = append(runs, job)
+			}
+		}
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].CreationTimestamp.Before(&runs[j].CreationTimestamp)
+	})
+
+	return runs
+}
+
+// DeleteJob deletes an existent job together with its pods as an async function. The input channel is closed, as
+// soon as the operation completes. All parameters are drawn from the environment on the command line.
+func DeleteJob(cmd *cobra.Command, jobName string) <-chan string {
+	res := make(chan string)
+
+	go func() {
+		defer close(res)
+		clientset, _, err := tools.GetUserClient(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		propagation := metav1.DeletePropagationForeground
+		err = clientset.BatchV1().Jobs(namespaceName).Delete(context.TODO(), jobName,
+			metav1.DeleteOptions{PropagationPolicy: &propagation})
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		//Check for the job been deleted from the system
+		timeout := 240
+		for timeout > 0 {
+			timeout--
+			time.Sleep(time.Millisecond * 250)
+			_, err := clientset.BatchV1().Jobs(namespaceName).Get(context.TODO(), jobName, metav1.GetOptions{})
+			if err != nil {
+				res <- ""
+				return
+			}
+		}
+		res <- "Operation timeout. Your job still exists. Please look after it with 'kufast get job'"
+	}()
+
+	return res
+}
+
+// DeleteCronJob deletes an existent cronjob together with all of its jobs as an async function. The input channel is
+// closed, as soon as the operation completes. All parameters are drawn from the environment on the command line.
+func DeleteCronJob(cmd *cobra.Command, cronJobName string) <-chan string {
+	res := make(chan string)
+
+	go func() {
+		defer close(res)
+		clientset, _, err := tools.GetUserClient(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		propagation := metav1.DeletePropagationForeground
+		err = clientset.BatchV1().CronJobs(namespaceName).Delete(context.TODO(), cronJobName,
+			metav1.DeleteOptions{PropagationPolicy: &propagation})
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		//Check for the cronjob been deleted from the system
+		timeout := 240
+		for timeout > 0 {
+			timeout--
+			time.Sleep(time.Millisecond * 250)
+			_, err := clientset.BatchV1().CronJobs(namespaceName).Get(context.TODO(), cronJobName, metav1.GetOptions{})
+			if err != nil {
+				res <- ""
+				return
+			}
+		}
+		res <- "Operation timeout. Your cronjob still exists. Please look after it with 'kufast get cronjob'"
+	}()
+
+	return res
+}
@@ -0,0 +1,384 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"github.com/spf13/cobra"
+	"io"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	n1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/strings/slices"
+	"kufast/objectFactory"
+	"kufast/tools"
+	"os"
+	"strings"
+)
+
+// manifestObject is an object of a Kubernetes manifest together with the API group and resource it belongs to.
+type manifestObject struct {
+	object   runtime.Object
+	meta     metav1.Object
+	kind     string
+	group    string
+	resource string
+}
+
+// ApplyManifest creates or updates the objects of Kubernetes manifests in a tenant-target. The namespace of all
+// objects is set to the tenant-target and pods get the labels and node selector of kufast pods. Kinds the tenant has
+// no permissions for are rejected. All objects are validated by the cluster first, including the ResourceQuota and
+// LimitRange of the tenant-target, and only applied, if all of them are valid. If applying an object fails
+// nevertheless, the objects before it stay applied. With --dry-run, the objects are only validated.
+// All parameters are drawn from the environment on the command line.
+func ApplyManifest(cmd *cobra.Command) ([]tools.ManifestResult, error) {
+	fileNames, _ := cmd.Flags().GetStringArray("file")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	target, _ := cmd.Flags().GetString("target")
+	if target != "" && !IsValidTarget(cmd, target, false) {
+		return nil, errors.New("Invalid target for tenant")
+	}
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []manifestObject
+	for _, fileName := range fileNames {
+		fileObjects, err := readManifest(fileName)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, fileObjects...)
+	}
+	if len(objects) == 0 {
+		return nil, errors.New("The manifests do not contain any objects.")
+	}
+
+	//Constrain all objects to the tenant-target and reject objects leaving it
+	results := make([]tools.ManifestResult, len(objects))
+	isValid := true
+	for i, object := range objects {
+		results[i] = tools.ManifestResult{Kind: object.kind, Name: object.meta.GetName()}
+		err = constrainManifestObject(cmd, object, namespaceName)
+		if err != nil {
+			results[i].Result = "rejected"
+			results[i].Message = err.Error()
+			isValid = false
+		}
+	}
+	if !isValid {
+		return skipRemainingObjects(results), errors.New("The manifests have not been applied, as objects have been rejected.")
+	}
+
+	//Let the cluster validate all objects before changing anything
+	for i, object := range objects {
+		action, err := applyManifestObject(clientset, object, namespaceName, []string{metav1.DryRunAll})
+		if err != nil {
+			results[i].Result = "invalid"
+			results[i].Message = err.Error()
+			isValid = false
+			continue
+		}
+		results[i].Result = "valid, would be " + action
+	}
+	if !isValid {
+		return skipRemainingObjects(results), errors.New("The manifests have not been applied, as objects are invalid.")
+	}
+	if dryRun {
+		return results, nil
+	}
+
+	for i, object := range objects {
+		action, err := applyManifestObject(clientset, object, namespaceName, nil)
+		if err != nil {
+			results[i].Result = "failed"
+			results[i].Message = err.Error()
+			return skipRemainingObjects(results), errors.New("Applying the manifests failed. Objects before the failed one have been applied.")
+		}
+		results[i].Result = action
+	}
+
+	return results, nil
+}
+
+// readManifest reads all objects of a manifest file with one or several YAML documents.
+func readManifest(fileName string) ([]manifestObject, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var objects []manifestObject
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(file))
+	for {
+		document, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.New(fileName + ": " + err.Error())
+		}
+		if strings.TrimSpace(string(document)) == "" {
+			continue
+		}
+
+		object, kind, err := scheme.Codecs.UniversalDeserializer().Decode(document, nil, nil)
+		if err != nil {
+			return nil, errors.New(fileName + ": " + err.Error())
+		}
+		objectMeta, err := meta.Accessor(object)
+		if err != nil {
+			return nil, errors.New(fileName + ": " + err.Error())
+		}
+		objects = append(objects, manifestObject{
+			object:   object,
+			meta:     objectMeta,
+			kind:     kind.Kind,
+			group:    kind.Group,
+			resource: kindToResource(kind.Kind),
+		})
+	}
+
+	return objects, nil
+}
+
+// kindToResource returns the name of the resource of a kind as used in roles, e.g. ingresses for Ingress.
+func kindToResource(kind string) string {
+	resource := strings.ToLower(kind)
+	if strings.HasSuffix(resource, "s") {
+		return resource + "es"
+	}
+	if strings.HasSuffix(resource, "y") {
+		return strings.TrimSuffix(resource, "y") + "ies"
+	}
+	return resource + "s"
+}
+
+// constrainManifestObject sets the namespace of a manifest object to the tenant-target and applies the restrictions
+// of kufast to it. An error is returned, if the object must not be created by the tenant.
+func constrainManifestObject(cmd *cobra.Command, object manifestObject, namespaceName string) error {
+	err := checkManifestObject(object, namespaceName)
+	if err != nil {
+		return err
+	}
+
+	tenantName := tools.GetTenantFromNamespace(namespaceName)
+	target, err := GetTargetFromTargetName(cmd, tools.GetTargetFromNamespace(namespaceName), tenantName, false)
+	if err != nil {
+		return err
+	}
+
+	if claim, ok := object.object.(*v1.PersistentVolumeClaim); ok {
+		storageClasses, err := GetTenantTargetStorageClasses(cmd, tenantName, target.Name)
+		if err != nil {
+			return err
+		}
+		storageClass := ""
+		if claim.Spec.StorageClassName != nil {
+			storageClass = *claim.Spec.StorageClassName
+		}
+		if len(storageClasses) > 0 && !slices.Contains(storageClasses, storageClass) {
+			return errors.New(storageClass + ": Storage class has to be one of " + strings.Join(storageClasses, ", ") + ".")
+		}
+	}
+
+	if ingress, ok := object.object.(*n1.Ingress); ok {
+		domains, err := GetTenantDomains(cmd, tenantName)
+		if err != nil {
+			return err
+		}
+		for _, rule := range ingress.Spec.Rules {
+			if !tools.IsAllowedDomain(rule.Host, domains) {
+				return tools.CreateDomainNotAllowedError(rule.Host, domains)
+			}
+		}
+	}
+
+	podMeta, podSpec := manifestPodTemplate(object.object)
+	if podSpec != nil {
+		placement, err := GetTenantTargetPlacement(cmd, tenantName, target.Name)
+		if err != nil {
+			return err
+		}
+		security, err := GetTenantTargetSecurityLevel(cmd, tenantName, target.Name)
+		if err != nil {
+			return err
+		}
+		objectFactory.ConstrainPodTemplate(podMeta, podSpec, namespaceName, object.meta.GetName(), target)
+		objectFactory.ApplyPlacementPolicy(podSpec, tenantName, placement)
+		objectFactory.ApplySecurityLevel(podSpec, security)
+	}
+
+	return nil
+}
+
+// checkManifestObject applies the restrictions of kufast, which do not depend on the settings of the tenant-target,
+// to a manifest object. The object is moved into the tenant-target and labels reserved for kufast are removed.
+// An error is returned, if the object must not be created by the tenant.
+func checkManifestObject(object manifestObject, namespaceName string) error {
+	isAllowed := false
+	for _, rule := range objectFactory.NewRole(namespaceName).Rules {
+		if slices.Contains(rule.APIGroups, object.group) && slices.Contains(rule.Resources, object.resource) &&
+			slices.Contains(rule.Verbs, "create") {
+			isAllowed = true
+		}
+	}
+	if !isAllowed {
+		return errors.New(object.kind + " objects cannot be managed by tenants.")
+	}
+
+	//Pods are only exposed through a NodePort with 'kufast expose', which checks the quota of the tenant-target
+	object.meta.SetNamespace(namespaceName)
+	labels := object.meta.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	delete(labels, tools.KUFAST_EXPOSED_LABEL)
+	labels[tools.KUFAST_TENANT_LABEL] = tools.GetTenantFromNamespace(namespaceName)
+	object.meta.SetLabels(labels)
+
+	if service, ok := object.object.(*v1.Service); ok {
+		if service.Spec.Type == v1.ServiceTypeLoadBalancer {
+			return errors.New("Services of type LoadBalancer are not supported. Please use 'kufast expose' with a NodePort.")
+		}
+		if len(service.Spec.ExternalIPs) > 0 {
+			return errors.New("Services must not have external IPs. Please use 'kufast expose' with a NodePort.")
+		}
+	}
+
+	podMeta, podSpec := manifestPodTemplate(object.object)
+	if podSpec != nil {
+		delete(podMeta.Labels, tools.KUFAST_EXPOSED_LABEL)
+		if podSpec.NodeName != "" {
+			return errors.New("Pods must not be assigned to a node directly. Pods are scheduled on the nodes of the target.")
+		}
+		for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+			_, hasCpu := container.Resources.Limits["cpu"]
+			_, hasMemory := container.Resources.Limits["memory"]
+			if !hasCpu || !hasMemory {
+				return errors.New("Container " + container.Name + " needs CPU and memory limits for the quota of the tenant-target.")
+			}
+		}
+	}
+
+	return nil
+}
+
+// manifestPodTemplate returns the metadata and spec of the pods created by a manifest object. Both are nil, if the
+// object creates no pods.
+func manifestPodTemplate(object runtime.Object) (*metav1.ObjectMeta, *v1.PodSpec) {
+	switch o := object.(type) {
+	case *v1.Pod:
+		return &o.ObjectMeta, &o.Spec
+	case *appsv1.Deployment:
+		return &o.Spec.Template.ObjectMeta, &o.Spec.Template.Spec
+	case *appsv1.ReplicaSet:
+		return &o.Spec.Template.ObjectMeta, &o.Spec.Template.Spec
+	case *batchv1.Job:
+		return &o.Spec.Template.ObjectMeta, &o.Spec.Template.Spec
+	case *batchv1.CronJob:
+		return &o.Spec.JobTemplate.Spec.Template.ObjectMeta, &o.Spec.JobTemplate.Spec.Template.Spec
+	}
+	return nil, nil
+}
+
+// applyManifestObject creates a manifest object or updates it, if it exists already. With dryRun set, the cluster only
+// validates the object. The action taken is returned.
+func applyManifestObject(clientset *kubernetes.Clientset, object manifestObject, namespaceName string, dryRun []string) (string, error) {
+	switch o := object.object.(type) {
+	case *v1.Pod:
+		return applyObject[*v1.Pod](clientset.CoreV1().Pods(namespaceName), o, dryRun)
+	case *v1.Secret:
+		return applyObject[*v1.Secret](clientset.CoreV1().Secrets(namespaceName), o, dryRun)
+	case *v1.Service:
+		return applyObject[*v1.Service](clientset.CoreV1().Services(namespaceName), o, dryRun)
+	case *v1.ConfigMap:
+		return applyObject[*v1.ConfigMap](clientset.CoreV1().ConfigMaps(namespaceName), o, dryRun)
+	case *v1.PersistentVolumeClaim:
+		return applyObject[*v1.PersistentVolumeClaim](clientset.CoreV1().PersistentVolumeClaims(namespaceName), o, dryRun)
+	case *appsv1.Deployment:
+		return applyObject[*appsv1.Deployment](clientset.AppsV1().Deployments(namespaceName), o, dryRun)
+	case *appsv1.ReplicaSet:
+		return applyObject[*appsv1.ReplicaSet](clientset.AppsV1().ReplicaSets(namespaceName), o, dryRun)
+	case *batchv1.Job:
+		return applyObject[*batchv1.Job](clientset.BatchV1().Jobs(namespaceName), o, dryRun)
+	case *batchv1.CronJob:
+		return applyObject[*batchv1.CronJob](clientset.BatchV1().CronJobs(namespaceName), o, dryRun)
+	case *n1.Ingress:
+		return applyObject[*n1.Ingress](clientset.NetworkingV1().Ingresses(namespaceName), o, dryRun)
+	}
+	return "", errors.New(object.kind + " objects are not supported.")
+}
+
+// objectClient is the part of the typed clients of client-go needed to apply objects of a kind.
+type objectClient[T metav1.Object] interface {
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (T, error)
+	Create(ctx context.Context, object T, opts metav1.CreateOptions) (T, error)
+	Update(ctx context.Context, object T, opts metav1.UpdateOptions) (T, error)
+}
+
+// applyObject creates an object or updates it, if it exists already.
+func applyObject[T metav1.Object](client objectClient[T], object T, dryRun []string) (string, error) {
+	existing, err := client.Get(context.TODO(), object.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = client.Create(context.TODO(), object, metav1.CreateOptions{DryRun: dryRun})
+		return "created", err
+	}
+	if err != nil {
+		return "", err
+	}
+
+	object.SetResourceVersion(existing.GetResourceVersion())
+	_, err = client.Update(context.TODO(), object, metav1.UpdateOptions{DryRun: dryRun})
+	return "updated", err
+}
+
+// skipRemainingObjects marks all objects without a result as not applied.
+func skipRemainingObjects(results []tools.ManifestResult) []tools.ManifestResult {
+	for i := range results {
+		if results[i].Result == "" || strings.HasPrefix(results[i].Result, "valid") {
+			results[i].Result = "not applied"
+		}
+	}
+	return results
+}
@@ -0,0 +1,92 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	n1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"kufast/tools"
+	"testing"
+)
+
+func TestCheckManifestObject(t *testing.T) {
+	limits := v1.ResourceRequirements{Limits: v1.ResourceList{"cpu": resource.MustParse("1"), "memory": resource.MustParse("1Gi")}}
+	exposed := map[string]string{tools.KUFAST_EXPOSED_LABEL: "true"}
+
+	tests := []struct {
+		name    string
+		object  runtime.Object
+		kind    string
+		group   string
+		wantErr bool
+	}{
+		{"pod with limits", &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "web", Resources: limits}}}}, "Pod", "", false},
+		{"pod without limits", &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "web"}}}}, "Pod", "", true},
+		{"pod assigned to a node", &v1.Pod{Spec: v1.PodSpec{NodeName: "node-1", Containers: []v1.Container{{Name: "web", Resources: limits}}}}, "Pod", "", true},
+		{"exposed deployment", &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: v1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: exposed}, Spec: v1.PodSpec{Containers: []v1.Container{{Name: "web", Resources: limits}}}}}},
+			"Deployment", "apps", false},
+		{"cluster ip service", &v1.Service{Spec: v1.ServiceSpec{Type: v1.ServiceTypeClusterIP}}, "Service", "", false},
+		{"load balancer service", &v1.Service{Spec: v1.ServiceSpec{Type: v1.ServiceTypeLoadBalancer}}, "Service", "", true},
+		{"service with external ips", &v1.Service{Spec: v1.ServiceSpec{ExternalIPs: []string{"203.0.113.1"}}}, "Service", "", true},
+		{"role", &rbacv1.Role{}, "Role", "rbac.authorization.k8s.io", true},
+		{"ingress", &n1.Ingress{}, "Ingress", "networking.k8s.io", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			objectMeta, err := meta.Accessor(test.object)
+			if err != nil {
+				t.Fatal(err)
+			}
+			objectMeta.SetLabels(map[string]string{tools.KUFAST_EXPOSED_LABEL: "true"})
+			object := manifestObject{object: test.object, meta: objectMeta, kind: test.kind, group: test.group, resource: kindToResource(test.kind)}
+
+			err = checkManifestObject(object, "acme-edge")
+			if (err != nil) != test.wantErr {
+				t.Fatalf("checkManifestObject() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if objectMeta.GetNamespace() != "acme-edge" || objectMeta.GetLabels()[tools.KUFAST_TENANT_LABEL] != "acme" {
+				t.Errorf("object not moved into tenant-target: namespace %q, labels %v", objectMeta.GetNamespace(), objectMeta.GetLabels())
+			}
+			if _, ok := objectMeta.GetLabels()[tools.KUFAST_EXPOSED_LABEL]; ok {
+				t.Errorf("exposed label of the object kept")
+			}
+			if podMeta, _ := manifestPodTemplate(test.object); podMeta != nil {
+				if _, ok := podMeta.Labels[tools.KUFAST_EXPOSED_LABEL]; ok {
+					t.Errorf("exposed label of the pods kept")
+				}
+			}
+		})
+	}
+}
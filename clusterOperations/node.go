@@ -0,0 +1,276 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	"context"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/strings/slices"
+	"kufast/tools"
+	"strings"
+)
+
+// GetNode returns a node of the cluster from its name.
+func GetNode(nodeName string, cmd *cobra.Command) (*v1.Node, error) {
+	//Configblock
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := clientset.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// SetNodeSchedulable cordons (schedulable = false) or uncordons (schedulable = true) a node.
+func SetNodeSchedulable(nodeName string, schedulable bool, cmd *cobra.Command) error {
+	//Configblock
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	node, err := clientset.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	node.Spec.Unschedulable = !schedulable
+	_, err = clientset.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IsNodeInTarget returns true, if the node is the target itself or a member of the target-group.
+func IsNodeInTarget(node *v1.Node, target tools.Target) bool {
+	if target.AccessType == "node" {
+		return node.ObjectMeta.Labels[tools.KUFAST_NODE_HOSTNAME_LABEL] == target.Name
+	}
+	return node.ObjectMeta.Labels[tools.KUFAST_NODE_GROUP_LABEL+target.Name] == "true"
+}
+
+// GetNodeGroups returns the names of all target-groups a node is a member of.
+func GetNodeGroups(node *v1.Node) []string {
+	var groups []string
+	for key, elem := range node.ObjectMeta.Labels {
+		if strings.HasPrefix(key, tools.KUFAST_NODE_GROUP_LABEL) && elem == "true" {
+			groups = append(groups, strings.TrimPrefix(key, tools.KUFAST_NODE_GROUP_LABEL))
+		}
+	}
+	return groups
+}
+
+// ListTenantTargetsOnNode lists all tenant-targets of all tenants, that are able to deploy to the node.
+func ListTenantTargetsOnNode(node *v1.Node, cmd *cobra.Command) ([]v1.Namespace, error) {
+	tenantTargets, err := ListAllTenantTargets(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []v1.Namespace
+	for _, tenantTarget := range tenantTargets {
+		target, err := GetTargetFromTenantTarget(&tenantTarget)
+		if err != nil {
+			continue
+		}
+		if IsNodeInTarget(node, target) {
+			results = append(results, tenantTarget)
+		}
+	}
+
+	return results, nil
+}
+
+// ListTenantPodsOnNode lists all pods of the given tenant-targets that are currently deployed on the node.
+func ListTenantPodsOnNode(nodeName string, tenantTargets []v1.Namespace, cmd *cobra.Command) ([]v1.Pod, error) {
+	//Configblock
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []v1.Pod
+	for _, tenantTarget := range tenantTargets {
+		list, err := clientset.CoreV1().Pods(tenantTarget.Name).List(context.TODO(), metav1.ListOptions{FieldSelector: "spec.nodeName=" + nodeName})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, list.Items...)
+	}
+
+	return results, nil
+}
+
+// GetNodeInfo returns the inventory of a single node.
+func GetNodeInfo(nodeName string, cmd *cobra.Command) (tools.NodeInfo, error) {
+	node, err := GetNode(nodeName, cmd)
+	if err != nil {
+		return tools.NodeInfo{}, err
+	}
+
+	infos, err := getNodeInfos([]v1.Node{*node}, cmd)
+	if err != nil {
+		return tools.NodeInfo{}, err
+	}
+
+	return infos[0], nil
+}
+
+// ListNodeInfos returns the inventory of all nodes of the cluster.
+func ListNodeInfos(cmd *cobra.Command) ([]tools.NodeInfo, error) {
+	//Configblock
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return getNodeInfos(nodes.Items, cmd)
+}
+
+// IsNodeReady returns true, if the node reports the condition Ready.
+func IsNodeReady(node *v1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// SumPodRequests returns the sum of the resource requests of the pods. Like the scheduler, the requests of init
+// containers and the pod overhead are taken into account, see podRequest.
+func SumPodRequests(pods []v1.Pod) v1.ResourceList {
+	sum := v1.ResourceList{}
+	for _, pod := range pods {
+		names := map[v1.ResourceName]bool{}
+		for _, containers := range [][]v1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+			for _, container := range containers {
+				for name := range container.Resources.Requests {
+					names[name] = true
+				}
+			}
+		}
+		for name := range pod.Spec.Overhead {
+			names[name] = true
+		}
+		for name := range names {
+			total := sum[name]
+			total.Add(podRequest(&pod, name))
+			sum[name] = total
+		}
+	}
+	return sum
+}
+
+// getNodeInfos collects the inventory of the given nodes. Pods, tenant-targets and tenants are only read once for all
+// nodes.
+func getNodeInfos(nodes []v1.Node, cmd *cobra.Command) ([]tools.NodeInfo, error) {
+	//Configblock
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	//Only pods that are not completed block resources on a node
+	pods, err := clientset.CoreV1().Pods("").List(context.TODO(), metav1.ListOptions{
+		FieldSelector: "status.phase!=" + string(v1.PodSucceeded) + ",status.phase!=" + string(v1.PodFailed),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tenantTargets, err := ListAllTenantTargets(cmd)
+	if err != nil {
+		return nil, err
+	}
+	var tenantTargetNames []string
+	for _, tenantTarget := range tenantTargets {
+		tenantTargetNames = append(tenantTargetNames, tenantTarget.Name)
+	}
+
+	tenants, err := clientset.CoreV1().ServiceAccounts("default").List(context.TODO(), metav1.ListOptions{LabelSelector: tools.KUFAST_TENANT_LABEL})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []tools.NodeInfo
+	for _, node := range nodes {
+		results = append(results, newNodeInfo(node, pods.Items, tenantTargetNames, tenants.Items))
+	}
+
+	return results, nil
+}
+
+// newNodeInfo creates the inventory of a node from all active pods of the cluster, the names of all tenant-targets and
+// all tenants.
+func newNodeInfo(node v1.Node, pods []v1.Pod, tenantTargetNames []string, tenants []v1.ServiceAccount) tools.NodeInfo {
+	hostname := node.ObjectMeta.Labels[tools.KUFAST_NODE_HOSTNAME_LABEL]
+
+	var nodePods []v1.Pod
+	tenantPods := 0
+	for _, pod := range pods {
+		if pod.Spec.NodeName != node.Name {
+			continue
+		}
+		nodePods = append(nodePods, pod)
+		if slices.Contains(tenantTargetNames, pod.Namespace) {
+			tenantPods++
+		}
+	}
+
+	var nodeTenants []string
+	for _, tenant := range tenants {
+		if tenant.ObjectMeta.Labels[tools.KUFAST_TENANT_NODEACCESS_LABEL+hostname] == "true" {
+			nodeTenants = append(nodeTenants, tenant.ObjectMeta.Labels[tools.KUFAST_TENANT_LABEL])
+		}
+	}
+
+	return tools.NodeInfo{
+		Name:          node.Name,
+		Hostname:      hostname,
+		Groups:        GetNodeGroups(&node),
+		Ready:         IsNodeReady(&node),
+		Unschedulable: node.Spec.Unschedulable,
+		Allocatable:   node.Status.Allocatable,
+		Requested:     SumPodRequests(nodePods),
+		Taints:        node.Spec.Taints,
+		Tenants:       nodeTenants,
+		TenantPods:    tenantPods,
+	}
+}
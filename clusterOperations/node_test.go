@@ -0,0 +1,139 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kufast/tools"
+	"reflect"
+	"testing"
+)
+
+// newTestContainer returns a container requesting the given amount of CPU and memory.
+func newTestContainer(cpu string, memory string) v1.Container {
+	return v1.Container{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+		"cpu":    resource.MustParse(cpu),
+		"memory": resource.MustParse(memory),
+	}}}
+}
+
+func TestSumPodRequests(t *testing.T) {
+	tests := []struct {
+		name       string
+		pods       []v1.Pod
+		wantCpu    string
+		wantMemory string
+	}{
+		{"no pods", nil, "0", "0"},
+		{"containers are summed", []v1.Pod{{Spec: v1.PodSpec{
+			Containers: []v1.Container{newTestContainer("100m", "64Mi"), newTestContainer("200m", "128Mi")},
+		}}}, "300m", "192Mi"},
+		{"smaller init container", []v1.Pod{{Spec: v1.PodSpec{
+			InitContainers: []v1.Container{newTestContainer("100m", "64Mi")},
+			Containers:     []v1.Container{newTestContainer("200m", "128Mi")},
+		}}}, "200m", "128Mi"},
+		{"largest init container", []v1.Pod{{Spec: v1.PodSpec{
+			InitContainers: []v1.Container{newTestContainer("500m", "64Mi"), newTestContainer("1", "32Mi")},
+			Containers:     []v1.Container{newTestContainer("200m", "128Mi")},
+		}}}, "1", "128Mi"},
+		{"pod overhead", []v1.Pod{{Spec: v1.PodSpec{
+			Containers: []v1.Container{newTestContainer("200m", "128Mi")},
+			Overhead:   v1.ResourceList{"cpu": resource.MustParse("250m"), "memory": resource.MustParse("120Mi")},
+		}}}, "450m", "248Mi"},
+		{"several pods", []v1.Pod{
+			{Spec: v1.PodSpec{Containers: []v1.Container{newTestContainer("200m", "128Mi")}}},
+			{Spec: v1.PodSpec{
+				InitContainers: []v1.Container{newTestContainer("1", "1Gi")},
+				Containers:     []v1.Container{newTestContainer("100m", "64Mi")},
+			}},
+		}, "1200m", "1152Mi"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := SumPodRequests(test.pods)
+			if cpu := got["cpu"]; cpu.Cmp(resource.MustParse(test.wantCpu)) != 0 {
+				t.Errorf("SumPodRequests() cpu = %v, want %s", cpu.String(), test.wantCpu)
+			}
+			if memory := got["memory"]; memory.Cmp(resource.MustParse(test.wantMemory)) != 0 {
+				t.Errorf("SumPodRequests() memory = %v, want %s", memory.String(), test.wantMemory)
+			}
+		})
+	}
+}
+
+func TestNewNodeInfo(t *testing.T) {
+	node := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{
+		tools.KUFAST_NODE_HOSTNAME_LABEL: "node-1",
+	}}}
+	newPod := func(namespaceName string, nodeName string) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespaceName},
+			Spec:       v1.PodSpec{NodeName: nodeName, Containers: []v1.Container{newTestContainer("100m", "64Mi")}},
+		}
+	}
+	newTenant := func(tenantName string, hostname string) v1.ServiceAccount {
+		return v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+			tools.KUFAST_TENANT_LABEL:                       tenantName,
+			tools.KUFAST_TENANT_NODEACCESS_LABEL + hostname: "true",
+		}}}
+	}
+
+	tests := []struct {
+		name              string
+		pods              []v1.Pod
+		tenantTargetNames []string
+		tenants           []v1.ServiceAccount
+		wantTenantPods    int
+		wantCpu           string
+		wantTenants       []string
+	}{
+		{"empty node", nil, []string{"alice-node-1"}, nil, 0, "0", nil},
+		{"tenant pods", []v1.Pod{newPod("alice-node-1", "node-1"), newPod("bob-edge", "node-1")},
+			[]string{"alice-node-1", "bob-edge"}, nil, 2, "200m", nil},
+		{"system pods are not counted", []v1.Pod{newPod("alice-node-1", "node-1"), newPod("kube-system", "node-1")},
+			[]string{"alice-node-1"}, nil, 1, "200m", nil},
+		{"pods of other nodes", []v1.Pod{newPod("alice-node-1", "node-1"), newPod("alice-edge", "node-2")},
+			[]string{"alice-node-1", "alice-edge"}, nil, 1, "100m", nil},
+		{"tenants with access", nil, nil, []v1.ServiceAccount{newTenant("alice", "node-1"), newTenant("bob", "node-2")},
+			0, "0", []string{"alice"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info := newNodeInfo(node, test.pods, test.tenantTargetNames, test.tenants)
+
+			if info.TenantPods != test.wantTenantPods {
+				t.Errorf("newNodeInfo() tenant pods = %d, want %d", info.TenantPods, test.wantTenantPods)
+			}
+			if cpu := info.Requested["cpu"]; cpu.Cmp(resource.MustParse(test.wantCpu)) != 0 {
+				t.Errorf("newNodeInfo() requested cpu = %v, want %s", cpu.String(), test.wantCpu)
+			}
+			if !reflect.DeepEqual(info.Tenants, test.wantTenants) {
+				t.Errorf("newNodeInfo() tenants = %v, want %v", info.Tenants, test.wantTenants)
+			}
+		})
+	}
+}
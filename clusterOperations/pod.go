@@ -29,16 +29,22 @@ import (
 	"errors"
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	"kufast/objectFactory"
 	"kufast/tools"
+	"strings"
 	"time"
 )
 
-// CreatePod creates a new pod as an async function. The input channel is closed, as soon as the operation
-// completes. All parameters are drawn from the environment on the command line.
+// CreatePod creates a new pod as an async function. The pod is recorded as first revision in its revision history. If
+// the revision cannot be recorded, a warning is sent after the result. The input channel is closed, as soon as the pod
+// has been created. Use WaitForPodStartup to wait for the pod to start. All parameters are drawn from the environment
+// on the command line.
 func CreatePod(cmd *cobra.Command, args []string) <-chan string {
-	res := make(chan string)
+	res := make(chan string, 2)
 
 	go func() {
 		defer close(res)
@@ -48,46 +54,30 @@ func CreatePod(cmd *cobra.Command, args []string) <-chan string {
 			return
 		}
 
-		ram, _ := cmd.Flags().GetString("memory")
-		cpu, _ := cmd.Flags().GetString("cpu")
-		storage, _ := cmd.Flags().GetString("storage")
-		keepAlive, _ := cmd.Flags().GetBool("keep-alive")
 		target, _ := cmd.Flags().GetString("target")
-		secrets, _ := cmd.Flags().GetStringArray("secrets")
-		deploySecret, _ := cmd.Flags().GetString("deploy-secret")
-		ports, _ := cmd.Flags().GetInt32Slice("port")
-		podCmd, _ := cmd.Flags().GetStringArray("cmd")
 
 		namespaceName, err := GetTenantTargetNameFromCmd(cmd)
 
 		if target == "" || IsValidTarget(cmd, target, false) {
 
-			podObject := objectFactory.NewPod(args[0], args[1], namespaceName, secrets, deploySecret, cpu, ram, storage, keepAlive, ports, podCmd)
-
-			_, err := clientset.CoreV1().Pods(namespaceName).Create(context.TODO(), podObject, metav1.CreateOptions{})
+			podObject, err := newPodFromCmd(cmd, args[0], args[1], namespaceName)
 			if err != nil {
 				res <- err.Error()
 				return
 			}
 
-			timeout := 30
-			for true {
-				timeout--
-
-				if timeout == 0 {
-					res <- "Operation timeout. Maybe your pod doesn't start correctly? Please look after it with 'kufast get pod'"
-				}
-
-				time.Sleep(time.Millisecond * 1000)
-				pod, err := clientset.CoreV1().Pods(namespaceName).Get(context.TODO(), args[0], metav1.GetOptions{})
-				if err != nil {
-					res <- err.Error()
-					return
-				}
-				if pod.Status.Phase == "Running" {
-					res <- ""
-					break
-				}
+			//The pod is usable without the revision history, so a failed recording is only reported
+			_, historyErr := addPodRevision(podObject, []tools.PodRevision{})
+
+			_, err = clientset.CoreV1().Pods(namespaceName).Create(context.TODO(), podObject, metav1.CreateOptions{})
+			if err != nil {
+				res <- podCreateError(err).Error()
+				return
+			}
+
+			res <- ""
+			if historyErr != nil {
+				res <- tools.CreateHistoryWarning(args[0], historyErr)
 			}
 		} else {
 			res <- errors.New("Invalid target for tenant").Error()
@@ -214,3 +204,482 @@ func ListTenantPods(cmd *cobra.Command) ([]v1.Pod, error) {
 	return results, nil
 
 }
+
+// ReschedulePod deletes an existing pod and recreates it from its own specification as an async function. The
+// scheduler is free to place the recreated pod on another node. The input channel is closed, as soon as the operation
+// completes.
+func ReschedulePod(pod v1.Pod, cmd *cobra.Command) <-chan string {
+	res := make(chan string)
+
+	go func() {
+		defer close(res)
+		clientset, _, err := tools.GetUserClient(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		if len(pod.OwnerReferences) > 0 {
+			res <- "Pod " + pod.Name + " in " + pod.Namespace + " is managed by a " + pod.OwnerReferences[0].Kind +
+				" and cannot be rescheduled directly."
+			return
+		}
+
+		err = recreatePod(clientset, objectFactory.NewPodFromPod(&pod))
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		res <- ""
+	}()
+
+	return res
+}
+
+// EvictPod evicts a pod owned by a controller like a deployment as an async function. The controller recreates the pod
+// on another node, while the pod disruption budgets of the tenant are respected. The input channel is closed, as soon
+// as the operation completes.
+func EvictPod(pod v1.Pod, cmd *cobra.Command) <-chan string {
+	res := make(chan string)
+
+	go func() {
+		defer close(res)
+		clientset, _, err := tools.GetUserClient(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		err = clientset.PolicyV1().Evictions(pod.Namespace).Evict(context.TODO(), &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+			},
+		})
+		if err != nil && !apierrors.IsNotFound(err) {
+			res <- "Pod " + pod.Name + " in " + pod.Namespace + " cannot be evicted: " + err.Error()
+			return
+		}
+
+		res <- ""
+	}()
+
+	return res
+}
+
+// UpdatePod recreates an existing pod with the pod flags changed on the command line as an async function. Everything
+// else is taken over from the existing pod. If the updated pod does not start, the previous pod is restored. If the
+// update cannot be recorded in the revision history, a warning is sent after the result of the update. The input
+// channel is closed, as soon as the operation completes. All parameters are drawn from the environment on the command
+// line.
+func UpdatePod(podName string, cmd *cobra.Command) <-chan string {
+	res := make(chan string, 2)
+
+	go func() {
+		defer close(res)
+		clientset, _, err := tools.GetUserClient(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		pod, err := GetPod(podName, cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		//Pods of a controller would be replaced by the controller with its own template
+		if len(pod.OwnerReferences) > 0 {
+			res <- tools.CreatePodHasOwnerError(podName, pod.OwnerReferences[0].Kind, pod.OwnerReferences[0].Name).Error()
+			return
+		}
+
+		previousPod := objectFactory.NewPodFromPod(pod)
+		updatedPod := objectFactory.NewPodFromPod(pod)
+		err = applyPodFlagsToSpec(cmd, &updatedPod.Spec)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		//The pod is usable without the revision history, so a failed recording is only reported. Pods created before
+		//the revision history existed or with an invalid history get their current specification as first revision.
+		revisions, historyErr := podRevisions(pod)
+		if len(revisions) == 0 {
+			revisions, err = addPodRevision(previousPod, []tools.PodRevision{})
+			if historyErr == nil {
+				historyErr = err
+			}
+		}
+		_, err = addPodRevision(updatedPod, revisions)
+		if historyErr == nil {
+			historyErr = err
+		}
+
+		err = recreatePod(clientset, updatedPod)
+		if err == nil {
+			err = waitForPodStart(clientset, pod.Namespace, podName, func(string) {})
+		}
+		if err != nil {
+			//Roll back to the previous specification
+			rollbackErr := recreatePod(clientset, previousPod)
+			if rollbackErr != nil {
+				res <- "The update failed: " + err.Error() + " The rollback failed as well: " + rollbackErr.Error()
+				return
+			}
+			res <- "The update failed: " + err.Error() + " The previous pod has been restored."
+			return
+		}
+
+		res <- ""
+		if historyErr != nil {
+			res <- tools.CreateHistoryWarning(podName, historyErr)
+		}
+	}()
+
+	return res
+}
+
+// recreatePod deletes a pod and creates the pod object with the same name, as soon as the old pod is removed.
+func recreatePod(clientset *kubernetes.Clientset, podObject *v1.Pod) error {
+	err := clientset.CoreV1().Pods(podObject.Namespace).Delete(context.TODO(), podObject.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	//Wait for the old pod to be removed, as the new one has the same name
+	timeout := 240
+	for true {
+		timeout--
+
+		if timeout == 0 {
+			return errors.New("Operation timeout. Pod " + podObject.Name + " still exists and was not recreated.")
+		}
+		time.Sleep(time.Millisecond * 250)
+		_, err := clientset.CoreV1().Pods(podObject.Namespace).Get(context.TODO(), podObject.Name, metav1.GetOptions{})
+		if err != nil {
+			break
+		}
+	}
+
+	_, err = clientset.CoreV1().Pods(podObject.Namespace).Create(context.TODO(), podObject, metav1.CreateOptions{})
+	if err != nil {
+		return podCreateError(err)
+	}
+	return nil
+}
+
+// podCreateError adds a hint to errors of the admission, if it rejects a pod because it requires more permissions than
+// the security level of its tenant-target allows or exceeds its quota. Other errors are returned unchanged.
+func podCreateError(err error) error {
+	if strings.Contains(err.Error(), "violates PodSecurity") {
+		return tools.CreatePodSecurityError(err)
+	}
+	if strings.Contains(err.Error(), "exceeded quota") {
+		return tools.CreateQuotaExceededError(err)
+	}
+	return err
+}
+
+// IsKeepAlivePod returns true, if the pod is restarted upon termination (created with --keep-alive or --restart
+// Always). Pods created before kufast labelled keep-alive pods are recognized by their restart policy. Pods owned by a
+// controller are no keep-alive pods, as the controller replaces them itself.
+func IsKeepAlivePod(pod v1.Pod) bool {
+	if len(pod.OwnerReferences) > 0 {
+		return false
+	}
+	keepAlive, isLabelled := pod.ObjectMeta.Labels[tools.KUFAST_KEEPALIVE_LABEL]
+	if !isLabelled {
+		return pod.Spec.RestartPolicy == v1.RestartPolicyAlways
+	}
+	return keepAlive == "true"
+}
+
+// newPodFromCmd creates a new pod object from the pod and container flags on the command line and applies the
+// placement policy of its tenant-target. The pod object is also used as template for other workloads like deployments.
+func newPodFromCmd(cmd *cobra.Command, podName string, imageName string, namespaceName string) (*v1.Pod, error) {
+	ram, _ := cmd.Flags().GetString("memory")
+	cpu, _ := cmd.Flags().GetString("cpu")
+	storage, _ := cmd.Flags().GetString("storage")
+	keepAlive, _ := cmd.Flags().GetBool("keep-alive")
+	restart, _ := cmd.Flags().GetString("restart")
+	secrets, _ := cmd.Flags().GetStringArray("secrets")
+	deploySecret, _ := cmd.Flags().GetString("deploy-secret")
+	ports, _ := cmd.Flags().GetInt32Slice("port")
+	podCmd, _ := cmd.Flags().GetStringArray("cmd")
+
+	//--keep-alive is kept as short form of --restart Always
+	var restartPolicy v1.RestartPolicy
+	if keepAlive {
+		restartPolicy = v1.RestartPolicyAlways
+	}
+	if restart != "" {
+		var err error
+		restartPolicy, err = tools.ParseRestartPolicy(restart)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	podObject := objectFactory.NewPod(podName, imageName, namespaceName, secrets, deploySecret, cpu, ram, storage, restartPolicy, ports, podCmd)
+	err := applyContainerFlagsToSpec(cmd, &podObject.Spec)
+	if err != nil {
+		return nil, err
+	}
+
+	//Add sidecars and init containers with their own resources, secrets are only introduced on request
+	sidecars, _ := cmd.Flags().GetStringArray("sidecar")
+	initContainers, _ := cmd.Flags().GetStringArray("init")
+	sidecarRam, _ := cmd.Flags().GetString("sidecar-memory")
+	sidecarCpu, _ := cmd.Flags().GetString("sidecar-cpu")
+	sidecarStorage, _ := cmd.Flags().GetString("sidecar-storage")
+	var sidecarSecrets []string
+	if withSecrets, _ := cmd.Flags().GetBool("sidecar-secrets"); withSecrets {
+		sidecarSecrets = secrets
+	}
+	specFileName, _ := cmd.Flags().GetString("spec-file")
+	for _, sidecar := range sidecars {
+		name, image, err := tools.ParseContainerFlag(sidecar)
+		if err != nil {
+			return nil, err
+		}
+		podObject.Spec.Containers = append(podObject.Spec.Containers,
+			objectFactory.NewContainer(name, image, nil, sidecarCpu, sidecarRam, sidecarStorage, nil, sidecarSecrets))
+	}
+	for _, initContainer := range initContainers {
+		name, image, err := tools.ParseContainerFlag(initContainer)
+		if err != nil {
+			return nil, err
+		}
+		podObject.Spec.InitContainers = append(podObject.Spec.InitContainers,
+			objectFactory.NewContainer(name, image, nil, sidecarCpu, sidecarRam, sidecarStorage, nil, sidecarSecrets))
+	}
+	if specFileName != "" {
+		specFile, err := tools.ReadPodSpecFile(specFileName)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range specFile.Containers {
+			podObject.Spec.Containers = append(podObject.Spec.Containers,
+				objectFactory.NewContainer(c.Name, c.Image, c.Cmd, c.Cpu, c.Memory, c.Storage, c.Ports, c.Secrets))
+		}
+		for _, c := range specFile.InitContainers {
+			podObject.Spec.InitContainers = append(podObject.Spec.InitContainers,
+				objectFactory.NewContainer(c.Name, c.Image, c.Cmd, c.Cpu, c.Memory, c.Storage, c.Ports, c.Secrets))
+		}
+	}
+
+	tenantName := tools.GetTenantFromNamespace(namespaceName)
+	placement, err := GetTenantTargetPlacement(cmd, tenantName, tools.GetTargetFromNamespace(namespaceName))
+	if err != nil {
+		return nil, err
+	}
+	objectFactory.ApplyPlacementPolicy(&podObject.Spec, tenantName, placement)
+	security, err := GetTenantTargetSecurityLevel(cmd, tenantName, tools.GetTargetFromNamespace(namespaceName))
+	if err != nil {
+		return nil, err
+	}
+	objectFactory.ApplySecurityLevel(&podObject.Spec, security)
+
+	return podObject, nil
+}
+
+// applyPodFlagsToSpec changes the main container of an existing pod spec according to the pod flags on the command
+// line. Only flags that have been set explicitly are applied, everything else stays untouched.
+func applyPodFlagsToSpec(cmd *cobra.Command, podSpec *v1.PodSpec) error {
+	container := &podSpec.Containers[0]
+
+	if cmd.Flags().Changed("image") {
+		container.Image, _ = cmd.Flags().GetString("image")
+	}
+
+	ram, _ := cmd.Flags().GetString("memory")
+	cpu, _ := cmd.Flags().GetString("cpu")
+	storage, _ := cmd.Flags().GetString("storage")
+	objectFactory.SetContainerResources(container, cpu, ram, storage)
+
+	if cmd.Flags().Changed("secrets") {
+		secrets, _ := cmd.Flags().GetStringArray("secrets")
+		objectFactory.SetSecretEnvVars(container, secrets)
+	}
+	if cmd.Flags().Changed("deploy-secret") {
+		deploySecret, _ := cmd.Flags().GetString("deploy-secret")
+		podSpec.ImagePullSecrets = objectFactory.NewImagePullSecrets(deploySecret)
+	}
+	if cmd.Flags().Changed("port") {
+		ports, _ := cmd.Flags().GetInt32Slice("port")
+		container.Ports = objectFactory.NewContainerPorts(ports)
+	}
+	if cmd.Flags().Changed("cmd") {
+		container.Command, _ = cmd.Flags().GetStringArray("cmd")
+	}
+
+	return applyContainerFlagsToSpec(cmd, podSpec)
+}
+
+// applyContainerFlagsToSpec sets the separate requests and limits, runtime options, environment variables, configmaps,
+// secret mounts, volumes and probes of the main container of a pod spec according to the flags on the command line. Variables from --env override variables with the same name from --env-file.
+func applyContainerFlagsToSpec(cmd *cobra.Command, podSpec *v1.PodSpec) error {
+	container := &podSpec.Containers[0]
+
+	//Separate limits and requests override the shared values of --cpu, --memory and --storage
+	cpuLimit, _ := cmd.Flags().GetString("cpu-limit")
+	ramLimit, _ := cmd.Flags().GetString("memory-limit")
+	storageLimit, _ := cmd.Flags().GetString("storage-limit")
+	objectFactory.SetContainerLimits(container, cpuLimit, ramLimit, storageLimit)
+	cpuRequest, _ := cmd.Flags().GetString("cpu-request")
+	ramRequest, _ := cmd.Flags().GetString("memory-request")
+	storageRequest, _ := cmd.Flags().GetString("storage-request")
+	objectFactory.SetContainerRequests(container, cpuRequest, ramRequest, storageRequest)
+
+	if cmd.Flags().Changed("args") {
+		container.Args, _ = cmd.Flags().GetStringArray("args")
+	}
+	if cmd.Flags().Changed("workdir") {
+		container.WorkingDir, _ = cmd.Flags().GetString("workdir")
+	}
+	if cmd.Flags().Changed("run-as-user") {
+		user, _ := cmd.Flags().GetInt64("run-as-user")
+		objectFactory.SetRunAsUser(container, user)
+	}
+	if cmd.Flags().Changed("pull-policy") {
+		pullPolicy, _ := cmd.Flags().GetString("pull-policy")
+		container.ImagePullPolicy = ""
+		if pullPolicy != "" {
+			policy, err := tools.ParsePullPolicy(pullPolicy)
+			if err != nil {
+				return err
+			}
+			container.ImagePullPolicy = policy
+		}
+	}
+
+	if cmd.Flags().Changed("env-file") {
+		envFile, _ := cmd.Flags().GetString("env-file")
+		variables, err := tools.ReadEnvFile(envFile)
+		if err != nil {
+			return err
+		}
+		for _, variable := range variables {
+			key, value, _ := tools.ParseKeyValue(variable)
+			objectFactory.SetEnvVar(container, key, value)
+		}
+	}
+	if cmd.Flags().Changed("env") {
+		variables, _ := cmd.Flags().GetStringArray("env")
+		for _, variable := range variables {
+			key, value, err := tools.ParseKeyValue(variable)
+			if err != nil {
+				return err
+			}
+			objectFactory.SetEnvVar(container, key, value)
+		}
+	}
+	if cmd.Flags().Changed("secret-env") {
+		references, _ := cmd.Flags().GetStringArray("secret-env")
+		for _, reference := range references {
+			name, secretName, key, err := tools.ParseSecretKeyRef(reference)
+			if err != nil {
+				return err
+			}
+			objectFactory.SetSecretKeyEnvVar(container, name, secretName, key)
+		}
+	}
+	if cmd.Flags().Changed("configmap-env") {
+		configMaps, _ := cmd.Flags().GetStringArray("configmap-env")
+		objectFactory.SetConfigMapEnvSources(container, configMaps)
+	}
+	if cmd.Flags().Changed("configmap-mount") {
+		mounts, _ := cmd.Flags().GetStringArray("configmap-mount")
+		var configMaps, mountPaths []string
+		for _, mount := range mounts {
+			configMapName, mountPath, err := tools.ParseMount(mount)
+			if err != nil {
+				return err
+			}
+			configMaps = append(configMaps, configMapName)
+			mountPaths = append(mountPaths, mountPath)
+		}
+		objectFactory.SetConfigMapVolumes(podSpec, container, configMaps, mountPaths)
+	}
+	if cmd.Flags().Changed("secret-mount") {
+		mounts, _ := cmd.Flags().GetStringArray("secret-mount")
+		var secrets, mountPaths []string
+		for _, mount := range mounts {
+			secretName, mountPath, err := tools.ParseMount(mount)
+			if err != nil {
+				return err
+			}
+			secrets = append(secrets, secretName)
+			mountPaths = append(mountPaths, mountPath)
+		}
+		objectFactory.SetSecretVolumes(podSpec, container, secrets, mountPaths)
+	}
+	if cmd.Flags().Changed("volume") {
+		mounts, _ := cmd.Flags().GetStringArray("volume")
+		var volumes, mountPaths []string
+		for _, mount := range mounts {
+			volumeName, mountPath, err := tools.ParseMount(mount)
+			if err != nil {
+				return err
+			}
+			volumes = append(volumes, volumeName)
+			mountPaths = append(mountPaths, mountPath)
+		}
+		objectFactory.SetPersistentVolumes(podSpec, container, volumes, mountPaths)
+	}
+
+	//Probes share the same timing settings, which are only applied together with a probe
+	if !cmd.Flags().Changed("liveness") && !cmd.Flags().Changed("readiness") && !cmd.Flags().Changed("startup") &&
+		(cmd.Flags().Changed("probe-delay") || cmd.Flags().Changed("probe-period") || cmd.Flags().Changed("probe-failures")) {
+		return errors.New(tools.ERROR_PROBE_TIMING_WITHOUT_PROBE)
+	}
+	delay, _ := cmd.Flags().GetInt32("probe-delay")
+	period, _ := cmd.Flags().GetInt32("probe-period")
+	failures, _ := cmd.Flags().GetInt32("probe-failures")
+	for _, probeType := range []string{"liveness", "readiness", "startup"} {
+		if !cmd.Flags().Changed(probeType) {
+			continue
+		}
+		probeValue, _ := cmd.Flags().GetString(probeType)
+		var probe *v1.Probe
+		if probeValue != "" {
+			kind, port, path, err := tools.ParseProbeFlag(probeValue)
+			if err != nil {
+				return err
+			}
+			probe = objectFactory.NewProbe(kind, port, path, delay, period, failures)
+		}
+		switch probeType {
+		case "liveness":
+			container.LivenessProbe = probe
+		case "readiness":
+			container.ReadinessProbe = probe
+		case "startup":
+			container.StartupProbe = probe
+		}
+	}
+
+	return nil
+}
+
+// GetPodContainer returns a container or init container of a pod by its name. Returns the main container, if the name
+// is empty.
+func GetPodContainer(pod *v1.Pod, containerName string) (*v1.Container, error) {
+	if containerName == "" {
+		return &pod.Spec.Containers[0], nil
+	}
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == containerName {
+			return &pod.Spec.Containers[i], nil
+		}
+	}
+	for i := range pod.Spec.InitContainers {
+		if pod.Spec.InitContainers[i].Name == containerName {
+			return &pod.Spec.InitContainers[i], nil
+		}
+	}
+	return nil, errors.New("Pod " + pod.Name + " has no container " + containerName)
+}
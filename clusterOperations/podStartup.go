@@ -0,0 +1,234 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	"context"
+	"errors"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/strings/slices"
+	"kufast/tools"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WaitForPodStartup watches a pod and its events until the pod is running and ready or, for pods that are not
+// restarted, completed. The progress of the startup (scheduling, image pulls, container starts) is passed to the
+// progress function. As soon as the startup cannot succeed without a change of the pod, an error with the reason and
+// the last log lines of the failing container is returned.
+func WaitForPodStartup(podName string, cmd *cobra.Command, progress func(string)) error {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	return waitForPodStart(clientset, namespaceName, podName, progress)
+}
+
+// waitForPodStart watches a pod and its events for at most two minutes. See WaitForPodStartup for details. A pod that
+// cannot be scheduled is reported at once, if no node has the resources, labels or tolerations it needs. Other
+// scheduling failures are only reported after the timeout, as the scheduler retries the pod, e.g. once a volume is
+// bound or a cordoned node is uncordoned.
+func waitForPodStart(clientset *kubernetes.Clientset, namespaceName string, podName string, progress func(string)) error {
+	podWatch, err := clientset.CoreV1().Pods(namespaceName).Watch(context.TODO(), metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", podName).String(),
+	})
+	if err != nil {
+		return err
+	}
+	defer podWatch.Stop()
+
+	eventWatch, err := clientset.CoreV1().Events(namespaceName).Watch(context.TODO(), metav1.ListOptions{
+		FieldSelector: fields.Set{"involvedObject.kind": "Pod", "involvedObject.name": podName}.String(),
+	})
+	if err != nil {
+		return err
+	}
+	defer eventWatch.Stop()
+
+	events := eventWatch.ResultChan()
+	lastStatus := "Waiting to be scheduled"
+	schedulingFailure := ""
+	var podUID types.UID
+	timeout := time.After(time.Second * 120)
+	for true {
+		select {
+		case event, ok := <-podWatch.ResultChan():
+			if !ok {
+				return errors.New("The watch of pod " + podName + " ended unexpectedly. Please look after it with 'kufast get pod'")
+			}
+			if event.Type == watch.Deleted {
+				return errors.New("Pod " + podName + " has been deleted during its startup.")
+			}
+			pod, isPod := event.Object.(*v1.Pod)
+			if !isPod {
+				continue
+			}
+			if pod.Status.Phase == v1.PodSucceeded || isPodReady(pod) {
+				return nil
+			}
+			podUID = pod.UID
+			if pod.Spec.NodeName != "" {
+				schedulingFailure = ""
+			}
+			err = podStartError(clientset, pod)
+			if err != nil {
+				return err
+			}
+
+		case event, ok := <-events:
+			if !ok {
+				//The pod watch is sufficient to detect the end of the startup
+				events = nil
+				continue
+			}
+			podEvent, isEvent := event.Object.(*v1.Event)
+			if !isEvent || event.Type == watch.Deleted {
+				continue
+			}
+			if podEvent.Reason == "FailedScheduling" {
+				schedulingFailure = podEvent.Message
+				//Events of a previous pod with the same name must not end the startup of the current one
+				if podEvent.InvolvedObject.UID == podUID && isPermanentSchedulingFailure(podEvent.Message) {
+					return errors.New("Pod " + podName + " cannot be scheduled: " + podEvent.Message +
+						" Please look after it with 'kufast explain pod'")
+				}
+			}
+			lastStatus = podEvent.Message
+			progress(" " + lastStatus)
+
+		case <-timeout:
+			if schedulingFailure != "" {
+				return errors.New("Pod " + podName + " could not be scheduled within 120 seconds: " + schedulingFailure +
+					" Please look after it with 'kufast explain pod'")
+			}
+			return errors.New("Operation timeout. Pod " + podName + " did not start within 120 seconds. Last status: " +
+				lastStatus + ". Please look after it with 'kufast get pod'")
+		}
+	}
+	return nil
+}
+
+// isPodReady returns true, if a pod is running and all of its containers are ready.
+func isPodReady(pod *v1.Pod) bool {
+	if pod.Status.Phase != v1.PodRunning {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// podStartError returns an error, if a pod failed or one of its containers cannot be started (e.g. because its image
+// cannot be pulled or runs as root in a tenant-target with the restricted security level). If a container has been
+// running before, the last lines of its log are added to the error.
+func podStartError(clientset kubernetes.Interface, pod *v1.Pod) error {
+	statuses := append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...)
+
+	if pod.Status.Phase == v1.PodFailed {
+		for _, status := range statuses {
+			terminated := status.State.Terminated
+			if terminated != nil && terminated.ExitCode != 0 {
+				return errors.New("Container " + status.Name + " of pod " + pod.Name + " failed: " + terminated.Reason +
+					" (exit code " + strconv.Itoa(int(terminated.ExitCode)) + ")" + podLogTail(clientset, pod, status.Name, false))
+			}
+		}
+		return errors.New("Pod " + pod.Name + " failed: " + pod.Status.Reason + " " + pod.Status.Message)
+	}
+
+	for _, status := range statuses {
+		if status.State.Waiting == nil || !slices.Contains(podStartFailureReasons, status.State.Waiting.Reason) {
+			continue
+		}
+		if status.State.Waiting.Reason == "CreateContainerConfigError" && strings.Contains(status.State.Waiting.Message, "runAsNonRoot") {
+			return tools.CreateImageRunsAsRootError(status.Name)
+		}
+		logTail := ""
+		if status.LastTerminationState.Terminated != nil {
+			logTail = podLogTail(clientset, pod, status.Name, true)
+		}
+		return errors.New("Container " + status.Name + " of pod " + pod.Name + " cannot be started: " +
+			status.State.Waiting.Reason + " " + status.State.Waiting.Message + logTail)
+	}
+	return nil
+}
+
+// isPermanentSchedulingFailure returns true, if the message of a FailedScheduling event only names reasons, which the
+// scheduler cannot resolve by retrying the pod: insufficient resources, node affinities or selectors that do not match
+// and taints the pod does not tolerate. Failures involving volumes are never permanent, as they resolve once the
+// volumes are bound.
+func isPermanentSchedulingFailure(message string) bool {
+	_, reasons, found := strings.Cut(message, "nodes are available: ")
+	if !found || strings.Contains(strings.ToLower(message), "volume") {
+		return false
+	}
+
+	//The outcome of the preemption is appended to the reasons and not relevant
+	reasons, _, _ = strings.Cut(reasons, " preemption:")
+	if !permanentSchedulingReasons.MatchString(reasons) {
+		return false
+	}
+	return strings.Trim(permanentSchedulingReasons.ReplaceAllString(reasons, ""), ", .") == ""
+}
+
+// permanentSchedulingReasons matches the reasons of FailedScheduling events, that do not resolve by retrying the pod.
+var permanentSchedulingReasons = regexp.MustCompile(`\d+ (Insufficient \S+|node\(s\) didn't match Pod's node affinity/selector|` +
+	`node\(s\) had untolerated taint \{[^}]*\}|node\(s\) had taint \{[^}]*\}, that the pod didn't tolerate)`)
+
+// podStartFailureReasons are the reasons of waiting containers, that do not resolve without a change of the pod.
+var podStartFailureReasons = []string{"ErrImagePull", "ImagePullBackOff", "InvalidImageName", "CrashLoopBackOff",
+	"CreateContainerConfigError", "CreateContainerError", "RunContainerError"}
+
+// podLogTail returns the last log lines of a container, prepared to be appended to an error message. With previous
+// set, the log of the last terminated instance of a restarted container is used. If no log is available, an empty
+// string is returned.
+func podLogTail(clientset kubernetes.Interface, pod *v1.Pod, containerName string, previous bool) string {
+	lines := int64(10)
+	log, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{
+		Container: containerName,
+		TailLines: &lines,
+		Previous:  previous,
+	}).DoRaw(context.TODO())
+	if err != nil || len(strings.TrimSpace(string(log))) == 0 {
+		return ""
+	}
+	return "\nLast log lines of container " + containerName + ":\n" + strings.TrimRight(string(log), "\n")
+}
@@ -0,0 +1,126 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"kufast/tools"
+	"strings"
+	"testing"
+)
+
+func TestPodStartError(t *testing.T) {
+	waiting := func(reason string, message string) v1.ContainerStatus {
+		return v1.ContainerStatus{Name: "web", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: reason, Message: message}}}
+	}
+	crashed := waiting("CrashLoopBackOff", "back-off restarting failed container")
+	crashed.LastTerminationState.Terminated = &v1.ContainerStateTerminated{ExitCode: 1}
+	terminated := v1.ContainerStatus{Name: "web", State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+		Reason: "Error", ExitCode: 2}}}
+
+	tests := []struct {
+		name    string
+		phase   v1.PodPhase
+		reason  string
+		status  v1.ContainerStatus
+		init    bool
+		wantErr string
+	}{
+		{"pending without statuses", v1.PodPending, "", v1.ContainerStatus{}, false, ""},
+		{"creating container", v1.PodPending, "", waiting("ContainerCreating", ""), false, ""},
+		{"initializing pod", v1.PodPending, "", waiting("PodInitializing", ""), false, ""},
+		{"image pull failed", v1.PodPending, "", waiting("ImagePullBackOff", "Back-off pulling image"), false,
+			"Container web of pod web cannot be started: ImagePullBackOff Back-off pulling image"},
+		{"init container image pull failed", v1.PodPending, "", waiting("ErrImagePull", "not found"), true,
+			"Container web of pod web cannot be started: ErrImagePull"},
+		{"invalid image name", v1.PodPending, "", waiting("InvalidImageName", "couldn't parse image reference"), false,
+			"cannot be started: InvalidImageName"},
+		{"run container error", v1.PodPending, "", waiting("RunContainerError", "exec: not found"), false,
+			"cannot be started: RunContainerError exec: not found"},
+		{"image runs as root", v1.PodPending, "", waiting("CreateContainerConfigError",
+			"container has runAsNonRoot and image will run as root"), false, tools.CreateImageRunsAsRootError("web").Error()},
+		{"missing secret", v1.PodPending, "", waiting("CreateContainerConfigError", "secret \"db\" not found"), false,
+			"cannot be started: CreateContainerConfigError secret \"db\" not found"},
+		{"crash loop with log", v1.PodRunning, "", crashed, false, "CrashLoopBackOff back-off restarting failed container\n" +
+			"Last log lines of container web:\nfake logs"},
+		{"failed container with log", v1.PodFailed, "", terminated, false, "Container web of pod web failed: Error (exit code 2)\n" +
+			"Last log lines of container web:\nfake logs"},
+		{"failed pod", v1.PodFailed, "Evicted", v1.ContainerStatus{}, false, "Pod web failed: Evicted"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "alice-edge"}}
+			pod.Status.Phase = test.phase
+			pod.Status.Reason = test.reason
+			if test.status.Name != "" && test.init {
+				pod.Status.InitContainerStatuses = []v1.ContainerStatus{test.status}
+			} else if test.status.Name != "" {
+				pod.Status.ContainerStatuses = []v1.ContainerStatus{test.status}
+			}
+
+			err := podStartError(fake.NewSimpleClientset(), pod)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Errorf("podStartError() = %v, want no error", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("podStartError() = %v, want an error containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestIsPermanentSchedulingFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    bool
+	}{
+		{"insufficient resources", "0/3 nodes are available: 1 Insufficient cpu, 2 Insufficient memory. preemption: " +
+			"0/3 nodes are available: 3 No preemption victims found for incoming pod.", true},
+		{"insufficient extended resource", "0/2 nodes are available: 2 Insufficient nvidia.com/gpu.", true},
+		{"node selector", "0/2 nodes are available: 2 node(s) didn't match Pod's node affinity/selector. preemption: " +
+			"0/2 nodes are available: 2 Preemption is not helpful for scheduling.", true},
+		{"untolerated taint", "0/3 nodes are available: 1 node(s) had untolerated taint {node-role.kubernetes.io/control-plane: }, " +
+			"2 Insufficient cpu.", true},
+		{"taint of older clusters", "0/1 nodes are available: 1 node(s) had taint {dedicated: gpu}, that the pod didn't tolerate.", true},
+		{"cordoned node", "0/3 nodes are available: 1 node(s) were unschedulable, 2 Insufficient cpu.", false},
+		{"unbound claim", "0/3 nodes are available: pod has unbound immediate PersistentVolumeClaims. preemption: " +
+			"0/3 nodes are available: 3 Preemption is not helpful for scheduling.", false},
+		{"volume affinity", "0/3 nodes are available: 1 node(s) had volume node affinity conflict, 2 Insufficient cpu.", false},
+		{"unknown message", "no nodes available to schedule pods", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isPermanentSchedulingFailure(test.message); got != test.want {
+				t.Errorf("isPermanentSchedulingFailure() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
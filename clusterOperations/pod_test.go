@@ -0,0 +1,59 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kufast/tools"
+	"testing"
+)
+
+func TestIsKeepAlivePod(t *testing.T) {
+	keepAliveLabels := map[string]string{tools.KUFAST_KEEPALIVE_LABEL: "true"}
+	owner := []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-5d8f7"}}
+
+	tests := []struct {
+		name string
+		pod  v1.Pod
+		want bool
+	}{
+		{"keep-alive label", v1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: keepAliveLabels}}, true},
+		{"label set to false", v1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{tools.KUFAST_KEEPALIVE_LABEL: "false"}},
+			Spec: v1.PodSpec{RestartPolicy: v1.RestartPolicyAlways}}, false},
+		{"unlabelled pod restarted always", v1.Pod{Spec: v1.PodSpec{RestartPolicy: v1.RestartPolicyAlways}}, true},
+		{"unlabelled pod never restarted", v1.Pod{Spec: v1.PodSpec{RestartPolicy: v1.RestartPolicyNever}}, false},
+		{"keep-alive label of a controller pod", v1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: keepAliveLabels, OwnerReferences: owner}}, false},
+		{"unlabelled controller pod", v1.Pod{ObjectMeta: metav1.ObjectMeta{OwnerReferences: owner},
+			Spec: v1.PodSpec{RestartPolicy: v1.RestartPolicyAlways}}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := IsKeepAlivePod(test.pod); got != test.want {
+				t.Errorf("IsKeepAlivePod() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
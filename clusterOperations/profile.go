@@ -0,0 +1,254 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	"context"
+	"errors"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kufast/objectFactory"
+	"kufast/tools"
+	"sigs.k8s.io/yaml"
+	"strings"
+)
+
+// CreateProfile saves a new pod profile, either from the pod flags on the command line or from an existing pod
+// (--from-pod). With --local, the profile is stored in the local profiles file of the user, otherwise in the
+// tenant-target to share it with everyone using the tenant. All parameters are drawn from the environment on the
+// command line.
+func CreateProfile(profileName string, cmd *cobra.Command) error {
+	fromPod, _ := cmd.Flags().GetString("from-pod")
+	isLocal, _ := cmd.Flags().GetBool("local")
+
+	profile := tools.NewProfileFromFlags(cmd)
+	if fromPod != "" {
+		pod, err := GetPod(fromPod, cmd)
+		if err != nil {
+			return err
+		}
+		profile = newProfileFromPod(pod)
+	}
+
+	if isLocal {
+		profiles, err := tools.ReadLocalProfiles()
+		if err != nil {
+			return err
+		}
+		profiles[profileName] = profile
+		return tools.WriteLocalProfiles(profiles)
+	}
+
+	content, err := yaml.Marshal(profile)
+	if err != nil {
+		return err
+	}
+
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	profiles, err := clientset.CoreV1().ConfigMaps(namespaceName).Get(context.TODO(), tools.KUFAST_PROFILES_CONFIGMAP, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		profiles, err = clientset.CoreV1().ConfigMaps(namespaceName).Create(context.TODO(),
+			objectFactory.NewProfilesConfigMap(namespaceName), metav1.CreateOptions{})
+	}
+	if err != nil {
+		return err
+	}
+	if profiles.Data == nil {
+		profiles.Data = map[string]string{}
+	}
+	//Configmaps created before the label existed are labeled on the next change
+	if profiles.Labels == nil {
+		profiles.Labels = map[string]string{}
+	}
+	profiles.Labels[tools.KUFAST_PROFILES_LABEL] = "true"
+	profiles.Data[profileName] = string(content)
+
+	_, err = clientset.CoreV1().ConfigMaps(namespaceName).Update(context.TODO(), profiles, metav1.UpdateOptions{})
+	return err
+}
+
+// GetProfile returns a pod profile by its name. Local profiles take precedence over profiles shared in the
+// tenant-target. Invalid shared profiles are skipped and returned as warnings, unless the profile itself is invalid.
+// All parameters are drawn from the environment on the command line.
+func GetProfile(profileName string, cmd *cobra.Command) (*tools.PodProfile, []string, error) {
+	localProfiles, err := tools.ReadLocalProfiles()
+	if err != nil {
+		return nil, nil, err
+	}
+	if profile, ok := localProfiles[profileName]; ok {
+		return &profile, nil, nil
+	}
+
+	sharedProfiles, invalidProfiles, err := ListSharedProfiles(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+	if profile, ok := sharedProfiles[profileName]; ok {
+		return &profile, invalidProfiles, nil
+	}
+	for _, invalidProfile := range invalidProfiles {
+		if strings.HasPrefix(invalidProfile, profileName+": ") {
+			return nil, nil, errors.New(invalidProfile)
+		}
+	}
+
+	return nil, invalidProfiles, errors.New("Profile " + profileName + " not found. Please look after it with 'kufast list profiles'")
+}
+
+// ListSharedProfiles returns all pod profiles shared in the tenant-target. Invalid profiles are skipped, so a single
+// broken profile does not affect the others. Their errors are returned as warnings instead. All parameters are drawn
+// from the environment on the command line.
+func ListSharedProfiles(cmd *cobra.Command) (map[string]tools.PodProfile, []string, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := map[string]tools.PodProfile{}
+	var invalidProfiles []string
+	profiles, err := clientset.CoreV1().ConfigMaps(namespaceName).Get(context.TODO(), tools.KUFAST_PROFILES_CONFIGMAP, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return result, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, profileName := range sortedKeys(profiles.Data) {
+		profile, err := tools.ParseProfile(profileName, profiles.Data[profileName])
+		if err != nil {
+			invalidProfiles = append(invalidProfiles, err.Error())
+			continue
+		}
+		result[profileName] = *profile
+	}
+	return result, invalidProfiles, nil
+}
+
+// DeleteProfile deletes a pod profile. With --local, the profile is removed from the local profiles file of the user,
+// otherwise from the tenant-target. All parameters are drawn from the environment on the command line.
+func DeleteProfile(profileName string, cmd *cobra.Command) error {
+	isLocal, _ := cmd.Flags().GetBool("local")
+	notFound := errors.New("Profile " + profileName + " not found. Please look after it with 'kufast list profiles'")
+
+	if isLocal {
+		profiles, err := tools.ReadLocalProfiles()
+		if err != nil {
+			return err
+		}
+		if _, ok := profiles[profileName]; !ok {
+			return notFound
+		}
+		delete(profiles, profileName)
+		return tools.WriteLocalProfiles(profiles)
+	}
+
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	profiles, err := clientset.CoreV1().ConfigMaps(namespaceName).Get(context.TODO(), tools.KUFAST_PROFILES_CONFIGMAP, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return notFound
+	}
+	if err != nil {
+		return err
+	}
+	if _, ok := profiles.Data[profileName]; !ok {
+		return notFound
+	}
+	delete(profiles.Data, profileName)
+
+	_, err = clientset.CoreV1().ConfigMaps(namespaceName).Update(context.TODO(), profiles, metav1.UpdateOptions{})
+	return err
+}
+
+// newProfileFromPod creates a pod profile from the main container and the restart policy of an existing pod.
+func newProfileFromPod(pod *v1.Pod) tools.PodProfile {
+	container := pod.Spec.Containers[0]
+	cpu := container.Resources.Limits["cpu"]
+	memory := container.Resources.Limits["memory"]
+	storage := container.Resources.Limits["ephemeral-storage"]
+	cpuRequest := container.Resources.Requests["cpu"]
+	memoryRequest := container.Resources.Requests["memory"]
+
+	profile := tools.PodProfile{
+		Image:   container.Image,
+		Cmd:     container.Command,
+		Restart: string(pod.Spec.RestartPolicy),
+	}
+	if !cpu.IsZero() {
+		profile.Cpu = cpu.String()
+	}
+	if !memory.IsZero() {
+		profile.Memory = memory.String()
+	}
+	if !storage.IsZero() {
+		profile.Storage = storage.String()
+	}
+	if !cpuRequest.IsZero() && !cpuRequest.Equal(cpu) {
+		profile.CpuRequest = cpuRequest.String()
+	}
+	if !memoryRequest.IsZero() && !memoryRequest.Equal(memory) {
+		profile.MemoryRequest = memoryRequest.String()
+	}
+	for _, envVar := range container.Env {
+		isSecretVar := envVar.ValueFrom != nil && envVar.ValueFrom.SecretKeyRef != nil &&
+			envVar.ValueFrom.SecretKeyRef.Name == envVar.Name && envVar.ValueFrom.SecretKeyRef.Key == "secret"
+		if isSecretVar {
+			profile.Secrets = append(profile.Secrets, envVar.Name)
+		}
+	}
+	for _, port := range container.Ports {
+		profile.Ports = append(profile.Ports, port.ContainerPort)
+	}
+	if len(pod.Spec.ImagePullSecrets) > 0 {
+		profile.DeploySecret = pod.Spec.ImagePullSecrets[0].Name
+	}
+
+	return profile
+}
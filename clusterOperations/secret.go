@@ -93,6 +93,33 @@ func CreateSecret(secretName string, secretData string, cmd *cobra.Command) erro
 	return nil
 }
 
+// CreateMultiKeySecret creates a new secret with several keys from literals and files. All parameters are drawn from
+// the cobra command.
+func CreateMultiKeySecret(secretName string, cmd *cobra.Command) error {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	secretData, err := readDataFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	secretObject := objectFactory.NewMultiKeySecret(namespaceName, secretName, secretData)
+
+	_, err = clientset.CoreV1().Secrets(namespaceName).Create(context.TODO(), secretObject, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 // GetSecret gets an existing secret. All parameters are drawn from the cobra command.
 func GetSecret(secretName string, cmd *cobra.Command) (*v1.Secret, error) {
 	//Initial config block
@@ -0,0 +1,222 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	"context"
+	"errors"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"kufast/objectFactory"
+	"kufast/tools"
+	"strings"
+	"time"
+)
+
+// ExposeWorkload creates a new service for a pod or a deployment. The ports are drawn from the port flag or, if none
+// are given, from the ports of the workload. Workloads exposed through a NodePort are labelled, so they are
+// reachable from outside of the tenant. All parameters are drawn from the environment on the command line.
+func ExposeWorkload(workloadName string, cmd *cobra.Command) (*v1.Service, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName, _ := cmd.Flags().GetString("name")
+	if serviceName == "" {
+		serviceName = workloadName
+	}
+	ports, _ := cmd.Flags().GetInt32Slice("port")
+	serviceTypeName, _ := cmd.Flags().GetString("type")
+
+	var serviceType v1.ServiceType
+	switch strings.ToLower(serviceTypeName) {
+	case "clusterip":
+		serviceType = v1.ServiceTypeClusterIP
+	case "nodeport":
+		serviceType = v1.ServiceTypeNodePort
+	default:
+		return nil, errors.New(serviceTypeName + ": Service type has to be either clusterip or nodeport.")
+	}
+
+	//Find the pod template of the workload. Deployments take precedence over pods with the same name.
+	var podSpec *v1.PodSpec
+	var podLabels map[string]string
+	deployment, deploymentErr := clientset.AppsV1().Deployments(namespaceName).Get(context.TODO(), workloadName, metav1.GetOptions{})
+	pod, podErr := clientset.CoreV1().Pods(namespaceName).Get(context.TODO(), workloadName, metav1.GetOptions{})
+	if deploymentErr == nil {
+		podSpec = &deployment.Spec.Template.Spec
+		podLabels = deployment.Spec.Template.Labels
+	} else if podErr == nil {
+		podSpec = &pod.Spec
+		podLabels = pod.Labels
+	} else {
+		return nil, errors.New("There is no pod or deployment " + workloadName + " in " + namespaceName)
+	}
+
+	if len(ports) == 0 {
+		for _, container := range podSpec.Containers {
+			for _, port := range container.Ports {
+				ports = append(ports, port.ContainerPort)
+			}
+		}
+	}
+	if len(ports) == 0 {
+		return nil, errors.New(tools.ERROR_NO_PORTS)
+	}
+
+	//Ensure the workload can be selected by the service and, with a NodePort, passes the network policies
+	if podLabels == nil {
+		podLabels = map[string]string{}
+	}
+	needsUpdate := podLabels[tools.KUFAST_WORKLOAD_LABEL] != workloadName ||
+		(serviceType == v1.ServiceTypeNodePort && podLabels[tools.KUFAST_EXPOSED_LABEL] != "true")
+	if needsUpdate {
+		podLabels[tools.KUFAST_WORKLOAD_LABEL] = workloadName
+		if serviceType == v1.ServiceTypeNodePort {
+			podLabels[tools.KUFAST_EXPOSED_LABEL] = "true"
+		}
+		if deploymentErr == nil {
+			deployment.Spec.Template.Labels = podLabels
+			_, err = clientset.AppsV1().Deployments(namespaceName).Update(context.TODO(), deployment, metav1.UpdateOptions{})
+		} else {
+			pod.Labels = podLabels
+			_, err = clientset.CoreV1().Pods(namespaceName).Update(context.TODO(), pod, metav1.UpdateOptions{})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	serviceObject := objectFactory.NewService(serviceName, namespaceName, workloadName, serviceType, ports)
+
+	service, err := clientset.CoreV1().Services(namespaceName).Create(context.TODO(), serviceObject, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+// ListTenantServices lists all services in all tenant-targets of a tenant.
+func ListTenantServices(cmd *cobra.Command) ([]v1.Service, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := ListTargetsFromCmd(cmd, false)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantName, err := GetTenantNameFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []v1.Service
+	for _, target := range targets {
+		list, err := clientset.CoreV1().Services(tenantName+"-"+target.Name).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, list.Items...)
+	}
+
+	return results, nil
+}
+
+// ListPodServices returns all services within the namespace of a pod that select the pod.
+// All parameters are drawn from the environment on the command line.
+func ListPodServices(namespaceName string, podLabels map[string]string, cmd *cobra.Command) ([]v1.Service, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := clientset.CoreV1().Services(namespaceName).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []v1.Service
+	for _, service := range list.Items {
+		if len(service.Spec.Selector) > 0 && labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(podLabels)) {
+			results = append(results, service)
+		}
+	}
+
+	return results, nil
+}
+
+// DeleteService deletes an existent service as an async function. The input channel is closed, as soon as the
+// operation completes. All parameters are drawn from the environment on the command line.
+func DeleteService(cmd *cobra.Command, serviceName string) <-chan string {
+	res := make(chan string)
+
+	go func() {
+		defer close(res)
+		clientset, _, err := tools.GetUserClient(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		err = clientset.CoreV1().Services(namespaceName).Delete(context.TODO(), serviceName, metav1.DeleteOptions{})
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		//Check for the service been deleted from the system
+		timeout := 80
+		for timeout > 0 {
+			timeout--
+			time.Sleep(time.Millisecond * 250)
+			_, err := clientset.CoreV1().Services(namespaceName).Get(context.TODO(), serviceName, metav1.GetOptions{})
+			if err != nil {
+				res <- ""
+				return
+			}
+		}
+		res <- "Operation timeout. Your service still exists. Please look after it with 'kufast list services'"
+	}()
+
+	return res
+}
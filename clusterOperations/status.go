@@ -0,0 +1,273 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	"context"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"kufast/tools"
+	"sort"
+	"time"
+)
+
+// GetTenantStatus returns the pods, the quotas and the latest events of all tenant-targets of a tenant. Quotas are left
+// out, if the role of a tenant-target does not allow to read them yet.
+func GetTenantStatus(cmd *cobra.Command) (*tools.TenantStatus, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	namespaceNames, err := listTenantTargetNames(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := ListTenantPods(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &tools.TenantStatus{Pods: pods, Quotas: map[string]v1.ResourceQuota{}}
+	for _, namespaceName := range namespaceNames {
+		quotas, err := clientset.CoreV1().ResourceQuotas(namespaceName).List(context.TODO(), metav1.ListOptions{})
+		if err != nil && !apierrors.IsForbidden(err) {
+			return nil, err
+		}
+		if err == nil && len(quotas.Items) > 0 {
+			status.Quotas[namespaceName] = quotas.Items[0]
+		}
+
+		events, err := clientset.CoreV1().Events(namespaceName).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range events.Items {
+			addStatusEvent(status, event)
+		}
+	}
+
+	return status, nil
+}
+
+// WatchTenantStatus watches the pods, the quotas and the events of all tenant-targets of a tenant and passes the
+// updated status to the update function on every change. The tenant is watched as well, so tenant-targets added or
+// removed in the meantime are watched from then on. The function only returns, if an error occurs.
+func WatchTenantStatus(cmd *cobra.Command, update func(*tools.TenantStatus)) error {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	for true {
+		status, err := GetTenantStatus(cmd)
+		if err != nil {
+			return err
+		}
+		update(status)
+
+		//Tenant-targets added or removed in the meantime are taken into account on every new watch
+		namespaceNames, err := listTenantTargetNames(cmd)
+		if err != nil {
+			return err
+		}
+		tenantName, err := GetTenantNameFromCmd(cmd)
+		if err != nil {
+			return err
+		}
+
+		//Watches are ended by the API server after a while, so the status is listed again afterwards
+		err = watchTenantStatus(clientset, tenantName, namespaceNames, status, update)
+		if err != nil {
+			return err
+		}
+		time.Sleep(time.Millisecond * 1000)
+	}
+	return nil
+}
+
+// watchTenantStatus applies the changes of the objects in the tenant-targets to the status, until one of the watches
+// ends or the tenant changes, e.g. as a tenant-target has been added or removed.
+func watchTenantStatus(clientset *kubernetes.Clientset, tenantName string, namespaceNames []string,
+	status *tools.TenantStatus, update func(*tools.TenantStatus)) error {
+	var watches []watch.Interface
+	defer func() {
+		for _, w := range watches {
+			w.Stop()
+		}
+	}()
+
+	//Roles of tenants created by older versions of kufast do not allow to watch the tenant
+	tenantWatch, err := clientset.CoreV1().ServiceAccounts("default").Watch(context.TODO(), metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", tenantName+"-user").String(),
+	})
+	if err != nil && !apierrors.IsForbidden(err) {
+		return err
+	}
+	if err == nil {
+		watches = append(watches, tenantWatch)
+	}
+
+	for _, namespaceName := range namespaceNames {
+		podWatch, err := clientset.CoreV1().Pods(namespaceName).Watch(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		watches = append(watches, podWatch)
+
+		eventWatch, err := clientset.CoreV1().Events(namespaceName).Watch(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+		watches = append(watches, eventWatch)
+
+		quotaWatch, err := clientset.CoreV1().ResourceQuotas(namespaceName).Watch(context.TODO(), metav1.ListOptions{})
+		if err != nil && !apierrors.IsForbidden(err) {
+			return err
+		}
+		if err == nil {
+			watches = append(watches, quotaWatch)
+		}
+	}
+
+	//Merge the results of all watches into a single channel
+	changes := make(chan watch.Event)
+	ended := make(chan struct{})
+	done := make(chan struct{})
+	defer close(done)
+	for _, w := range watches {
+		go func(results <-chan watch.Event) {
+			for change := range results {
+				select {
+				case changes <- change:
+				case <-done:
+					return
+				}
+			}
+			select {
+			case ended <- struct{}{}:
+			case <-done:
+			}
+		}(w.ResultChan())
+	}
+
+	for true {
+		select {
+		case change := <-changes:
+			//The tenant-targets are listed again, as soon as the tenant changes
+			if _, isTenant := change.Object.(*v1.ServiceAccount); isTenant {
+				if change.Type == watch.Added {
+					continue
+				}
+				return nil
+			}
+			applyStatusChange(status, change)
+
+			//Apply all pending changes before the update, as changes often arrive in bursts
+			pending := true
+			for pending {
+				select {
+				case change = <-changes:
+					applyStatusChange(status, change)
+				default:
+					pending = false
+				}
+			}
+			update(status)
+		case <-ended:
+			return nil
+		}
+	}
+	return nil
+}
+
+// applyStatusChange applies a change reported by a watch to the status of a tenant.
+func applyStatusChange(status *tools.TenantStatus, change watch.Event) {
+	switch object := change.Object.(type) {
+	case *v1.Pod:
+		for i, pod := range status.Pods {
+			if pod.Namespace == object.Namespace && pod.Name == object.Name {
+				status.Pods = append(status.Pods[:i], status.Pods[i+1:]...)
+				break
+			}
+		}
+		if change.Type != watch.Deleted {
+			status.Pods = append(status.Pods, *object)
+		}
+	case *v1.ResourceQuota:
+		if change.Type == watch.Deleted {
+			delete(status.Quotas, object.Namespace)
+		} else {
+			status.Quotas[object.Namespace] = *object
+		}
+	case *v1.Event:
+		if change.Type != watch.Deleted {
+			addStatusEvent(status, *object)
+		}
+	}
+}
+
+// addStatusEvent adds an event to the event feed of a status or replaces it, if it is repeated. Only the latest events
+// are kept.
+func addStatusEvent(status *tools.TenantStatus, event v1.Event) {
+	for i, existing := range status.Events {
+		if existing.UID == event.UID {
+			status.Events = append(status.Events[:i], status.Events[i+1:]...)
+			break
+		}
+	}
+	status.Events = append(status.Events, event)
+	sort.SliceStable(status.Events, func(i, j int) bool {
+		return tools.GetEventTime(status.Events[i]).Before(tools.GetEventTime(status.Events[j]))
+	})
+	if len(status.Events) > tools.KUFAST_STATUS_EVENT_LIMIT {
+		status.Events = status.Events[len(status.Events)-tools.KUFAST_STATUS_EVENT_LIMIT:]
+	}
+}
+
+// listTenantTargetNames returns the namespace names of all tenant-targets of a tenant.
+func listTenantTargetNames(cmd *cobra.Command) ([]string, error) {
+	targets, err := ListTargetsFromCmd(cmd, false)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantName, err := GetTenantNameFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var namespaceNames []string
+	for _, target := range targets {
+		namespaceNames = append(namespaceNames, tenantName+"-"+target.Name)
+	}
+	return namespaceNames, nil
+}
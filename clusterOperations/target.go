@@ -28,9 +28,15 @@ import (
 	"context"
 	"errors"
 	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/strings/slices"
+	"kufast/objectFactory"
 	"kufast/tools"
+	"sort"
 	"strings"
 )
 
@@ -162,37 +168,122 @@ func ListTargetsFromCmd(cmd *cobra.Command, all bool) ([]tools.Target, error) {
 
 }
 
-// SetTargetGroupToNodes Adds all nodes from the array to a target-group. Overwrites previous config.
+// SetTargetGroupToNodes Adds all nodes from the array to a new target-group. Fails, if the target already exists.
 func SetTargetGroupToNodes(targetName string, targetNodes []string, cmd *cobra.Command) error {
+	if IsValidTarget(cmd, targetName, true) {
+		return errors.New("Target " + targetName + " already exists. Use 'kufast update target-group' to change its nodes.")
+	}
+
+	return labelTargetGroupNodes(targetName, targetNodes, cmd)
+}
+
+// UpdateTargetGroupNodes sets the nodes of an existing target-group. Overwrites previous config.
+func UpdateTargetGroupNodes(targetName string, targetNodes []string, cmd *cobra.Command) error {
+	if !IsValidTarget(cmd, targetName, true) {
+		return errors.New("Target-group " + targetName + " does not exist.")
+	}
+
+	return labelTargetGroupNodes(targetName, targetNodes, cmd)
+}
+
+// DeleteTargetGroupFromNodes removes a target-group from all nodes.
+func DeleteTargetGroupFromNodes(targetName string, cmd *cobra.Command) error {
 	clientset, _, err := tools.GetUserClient(cmd)
 	if err != nil {
 		return errors.New(err.Error())
 	}
 
-	nodeList, err := clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if !IsValidTarget(cmd, targetName, true) {
+		return errors.New("Target-group " + targetName + " does not exist.")
+	}
+
+	nodeList, err := clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{LabelSelector: tools.KUFAST_NODE_GROUP_LABEL + targetName})
 	if err != nil {
 		return errors.New(err.Error())
 	}
+	for _, node := range nodeList.Items {
+		err = setNodeLabel(node.Name, tools.KUFAST_NODE_GROUP_LABEL+targetName, "", cmd)
+		if err != nil {
+			return err
+		}
+	}
 
-	if !IsValidTarget(cmd, targetName, true) {
-		for _, node := range nodeList.Items {
-			if slices.Contains(targetNodes, node.Name) {
-				node.ObjectMeta.Labels["kufast.group/"+targetName] = "true"
-			} else {
-				node.ObjectMeta.Labels["kufast.group/"+targetName] = "false"
+	return VerifyTargetGroupNodes(targetName, nil, cmd)
+}
+
+// VerifyTargetGroupNodes returns an error, if the members of the target-group on the cluster differ from the
+// expected nodes. If no nodes are expected, no node may carry the target-group label anymore.
+func VerifyTargetGroupNodes(targetName string, expectedNodes []string, cmd *cobra.Command) error {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	nodeList, err := clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{LabelSelector: tools.KUFAST_NODE_GROUP_LABEL + targetName})
+	if err != nil {
+		return err
+	}
+
+	var mismatches []string
+	for _, node := range nodeList.Items {
+		isMember := node.ObjectMeta.Labels[tools.KUFAST_NODE_GROUP_LABEL+targetName] == "true"
+		if len(expectedNodes) == 0 || isMember != slices.Contains(expectedNodes, node.Name) {
+			mismatches = append(mismatches, node.Name)
+		}
+	}
+
+	if len(mismatches) > 0 {
+		return errors.New("Verification failed. The target-group label of these nodes does not match the expected state: " +
+			strings.Join(mismatches, ", "))
+	}
+	return nil
+}
+
+// GetTargetGroupImpact computes the impact of a change of the nodes of a target-group. The result contains all tenants
+// with access to the group, all tenant-targets deploying to it and all pods, that run on a node which is not part of
+// the remaining nodes anymore. Pass no remaining nodes to get the impact of a deletion of the group.
+func GetTargetGroupImpact(groupName string, remainingNodes []string, cmd *cobra.Command) (tools.TargetGroupImpact, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return tools.TargetGroupImpact{}, err
+	}
+
+	tenants, err := ListTenantsWithGroupAccess(groupName, cmd)
+	if err != nil {
+		return tools.TargetGroupImpact{}, err
+	}
+
+	tenantTargets, err := ListTenantTargetsOnTargetGroup(groupName, cmd)
+	if err != nil {
+		return tools.TargetGroupImpact{}, err
+	}
+
+	impact := tools.TargetGroupImpact{
+		Tenants: tenants,
+	}
+	for _, tenantTarget := range tenantTargets {
+		impact.TenantTargets = append(impact.TenantTargets, tenantTarget.Name)
+
+		pods, err := clientset.CoreV1().Pods(tenantTarget.Name).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return tools.TargetGroupImpact{}, err
+		}
+		for _, pod := range pods.Items {
+			if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+				continue
 			}
-			_, err = clientset.CoreV1().Nodes().Update(context.TODO(), &node, metav1.UpdateOptions{})
-			if err != nil {
-				return errors.New(err.Error())
+			if len(remainingNodes) == 0 || (pod.Spec.NodeName != "" && !slices.Contains(remainingNodes, pod.Spec.NodeName)) {
+				impact.Pods = append(impact.Pods, pod)
 			}
 		}
 	}
 
-	return nil
+	return impact, nil
 }
 
-// DeleteTargetGroupFromNodes removes a target-group from all nodes.
-func DeleteTargetGroupFromNodes(targetName string, cmd *cobra.Command) error {
+// labelTargetGroupNodes sets the target-group label of all nodes. Nodes within targetNodes become members of the
+// group, all other nodes are explicitly excluded. Afterwards the labels are verified.
+func labelTargetGroupNodes(targetName string, targetNodes []string, cmd *cobra.Command) error {
 	clientset, _, err := tools.GetUserClient(cmd)
 	if err != nil {
 		return errors.New(err.Error())
@@ -202,14 +293,274 @@ func DeleteTargetGroupFromNodes(targetName string, cmd *cobra.Command) error {
 	if err != nil {
 		return errors.New(err.Error())
 	}
-	if IsValidTarget(cmd, targetName, true) {
-		for _, node := range nodeList.Items {
-			delete(node.ObjectMeta.Labels, "kufast.group/"+targetName)
-			_, err = clientset.CoreV1().Nodes().Update(context.TODO(), &node, metav1.UpdateOptions{})
-			if err != nil {
-				return errors.New(err.Error())
-			}
+
+	//Ensure all nodes exist before anything is changed
+	var nodeNames []string
+	for _, node := range nodeList.Items {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	for _, targetNode := range targetNodes {
+		if !slices.Contains(nodeNames, targetNode) {
+			return errors.New("Node " + targetNode + " does not exist.")
+		}
+	}
+
+	for _, node := range nodeList.Items {
+		value := "false"
+		if slices.Contains(targetNodes, node.Name) {
+			value = "true"
+		}
+		err = setNodeLabel(node.Name, tools.KUFAST_NODE_GROUP_LABEL+targetName, value, cmd)
+		if err != nil {
+			return err
+		}
+	}
+
+	return VerifyTargetGroupNodes(targetName, targetNodes, cmd)
+}
+
+// setNodeLabel sets a label of a node to a value. An empty value removes the label. Conflicting updates of the node
+// are retried.
+func setNodeLabel(nodeName string, key string, value string, cmd *cobra.Command) error {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := clientset.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			delete(node.ObjectMeta.Labels, key)
+		} else {
+			node.ObjectMeta.Labels[key] = value
+		}
+		_, err = clientset.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// SetTargetGroupQuota creates or updates the quota of a whole target-group. Only the limits set on the command line
+// are changed. Fails, if the tenant-targets on the group already allocate more than the new quota.
+func SetTargetGroupQuota(groupName string, cmd *cobra.Command) error {
+	ram, _ := cmd.Flags().GetString("memory")
+	cpu, _ := cmd.Flags().GetString("cpu")
+	storage, _ := cmd.Flags().GetString("storage")
+	pods, _ := cmd.Flags().GetString("pods")
+
+	if ram == "" && cpu == "" && storage == "" && pods == "" {
+		return nil
+	}
+
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	quotaObject := objectFactory.NewTargetGroupQuota(groupName, ram, cpu, storage, pods)
+
+	exists := false
+	existingQuota, err := clientset.CoreV1().ConfigMaps("default").Get(context.TODO(), quotaObject.Name, metav1.GetOptions{})
+	if err == nil {
+		exists = true
+		for key, value := range quotaObject.Data {
+			existingQuota.Data[key] = value
+		}
+		quotaObject = existingQuota
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	allocated, err := GetTargetGroupAllocation(groupName, "", cmd)
+	if err != nil {
+		return err
+	}
+	for key, value := range quotaObject.Data {
+		allocatedQty := allocated[v1.ResourceName(key)]
+		if allocatedQty.Cmp(resource.MustParse(value)) > 0 {
+			return errors.New("Quota too small for target-group " + groupName + ": " + key + " " + allocatedQty.String() +
+				" already allocated by tenant-targets, but quota is " + value)
 		}
 	}
+
+	if exists {
+		_, err = clientset.CoreV1().ConfigMaps("default").Update(context.TODO(), quotaObject, metav1.UpdateOptions{})
+	} else {
+		_, err = clientset.CoreV1().ConfigMaps("default").Create(context.TODO(), quotaObject, metav1.CreateOptions{})
+	}
+	return err
+}
+
+// GetTargetGroupQuota returns the quota of a target-group. Returns nil, if the target-group has no quota.
+func GetTargetGroupQuota(groupName string, cmd *cobra.Command) (v1.ResourceList, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	quotaObject, err := clientset.CoreV1().ConfigMaps("default").Get(context.TODO(), groupName+"-groupquota", metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	quota := v1.ResourceList{}
+	for key, value := range quotaObject.Data {
+		qty, err := resource.ParseQuantity(value)
+		if err != nil {
+			return nil, err
+		}
+		quota[v1.ResourceName(key)] = qty
+	}
+	return quota, nil
+}
+
+// DeleteTargetGroupQuota removes the quota of a target-group, if one exists.
+func DeleteTargetGroupQuota(groupName string, cmd *cobra.Command) error {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	err = clientset.CoreV1().ConfigMaps("default").Delete(context.TODO(), groupName+"-groupquota", metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
 	return nil
 }
+
+// ListTenantTargetsOnTargetGroup lists all tenant-targets of all tenants, that deploy to the target-group.
+func ListTenantTargetsOnTargetGroup(groupName string, cmd *cobra.Command) ([]v1.Namespace, error) {
+	tenantTargets, err := ListAllTenantTargets(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []v1.Namespace
+	for _, tenantTarget := range tenantTargets {
+		target, err := GetTargetFromTenantTarget(&tenantTarget)
+		if err == nil && target.AccessType == "group" && target.Name == groupName {
+			results = append(results, tenantTarget)
+		}
+	}
+	return results, nil
+}
+
+// GetTargetGroupAllocation returns the sum of the quotas of all tenant-targets on a target-group. The tenant-target
+// passed as excludedTenantTarget is not taken into account.
+func GetTargetGroupAllocation(groupName string, excludedTenantTarget string, cmd *cobra.Command) (v1.ResourceList, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantTargets, err := ListTenantTargetsOnTargetGroup(groupName, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	allocated := v1.ResourceList{}
+	for _, tenantTarget := range tenantTargets {
+		if tenantTarget.Name == excludedTenantTarget {
+			continue
+		}
+		quota, err := clientset.CoreV1().ResourceQuotas(tenantTarget.Name).Get(context.TODO(), tenantTarget.Name+"-limits", metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		for name, qty := range quota.Spec.Hard {
+			total := allocated[name]
+			total.Add(qty)
+			allocated[name] = total
+		}
+	}
+	return allocated, nil
+}
+
+// CheckTargetGroupQuota returns an error, if the requested quota of a tenant-target does not fit into the remaining
+// quota of its target-group. The tenant-target passed as excludedTenantTarget is not taken into account, e.g. when
+// it gets updated.
+func CheckTargetGroupQuota(groupName string, excludedTenantTarget string, requested v1.ResourceList, cmd *cobra.Command) error {
+	quota, err := GetTargetGroupQuota(groupName, cmd)
+	if err != nil || quota == nil {
+		return err
+	}
+
+	allocated, err := GetTargetGroupAllocation(groupName, excludedTenantTarget, cmd)
+	if err != nil {
+		return err
+	}
+
+	violations := targetGroupQuotaViolations(quota, allocated, requested)
+	if len(violations) > 0 {
+		return errors.New("Quota of target-group " + groupName + " exceeded:\n" + strings.Join(violations, "\n"))
+	}
+	return nil
+}
+
+// targetGroupQuotaViolations compares the requested quota of a tenant-target with the quota of its target-group minus
+// the quota allocated by the other tenant-targets. A description of each exceeded resource is returned, ordered by the
+// name of the resource.
+func targetGroupQuotaViolations(quota v1.ResourceList, allocated v1.ResourceList, requested v1.ResourceList) []string {
+	var violations []string
+	for name, limit := range quota {
+		requestedQty, ok := requested[name]
+		if !ok {
+			violations = append(violations, string(name)+": the target-group has a quota of "+limit.String()+
+				", so the tenant-target needs a limit as well")
+			continue
+		}
+		allocatedQty := allocated[name]
+		available := limit.DeepCopy()
+		available.Sub(allocatedQty)
+		if requestedQty.Cmp(available) > 0 {
+			violations = append(violations, string(name)+": "+requestedQty.String()+" requested, but only "+
+				available.String()+" of "+limit.String()+" available")
+		}
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+// ListTargetGroupNodes returns the names of all nodes that are a member of the target-group.
+func ListTargetGroupNodes(groupName string, cmd *cobra.Command) ([]string, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeList, err := clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{LabelSelector: tools.KUFAST_NODE_GROUP_LABEL + groupName + "=true"})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []string
+	for _, node := range nodeList.Items {
+		results = append(results, node.Name)
+	}
+	return results, nil
+}
+
+// ListTenantsWithGroupAccess returns the names of all tenants that have access to the target-group.
+func ListTenantsWithGroupAccess(groupName string, cmd *cobra.Command) ([]string, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	tenants, err := clientset.CoreV1().ServiceAccounts("default").List(context.TODO(), metav1.ListOptions{LabelSelector: tools.KUFAST_TENANT_GROUPACCESS_LABEL + groupName + "=true"})
+	if err != nil {
+		return nil, err
+	}
+
+	var results []string
+	for _, tenant := range tenants.Items {
+		results = append(results, tenant.ObjectMeta.Labels[tools.KUFAST_TENANT_LABEL])
+	}
+	return results, nil
+}
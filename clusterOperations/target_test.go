@@ -0,0 +1,75 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"reflect"
+	"testing"
+)
+
+func TestTargetGroupQuotaViolations(t *testing.T) {
+	quota := v1.ResourceList{
+		"limits.memory": resource.MustParse("8Gi"),
+		"limits.cpu":    resource.MustParse("4"),
+	}
+	allocated := v1.ResourceList{
+		"limits.memory": resource.MustParse("6Gi"),
+		"limits.cpu":    resource.MustParse("1"),
+	}
+
+	tests := []struct {
+		name      string
+		allocated v1.ResourceList
+		requested v1.ResourceList
+		want      []string
+	}{
+		{"fits into the remaining quota", allocated,
+			v1.ResourceList{"limits.memory": resource.MustParse("2Gi"), "limits.cpu": resource.MustParse("3")}, nil},
+		{"nothing allocated yet", v1.ResourceList{},
+			v1.ResourceList{"limits.memory": resource.MustParse("8Gi"), "limits.cpu": resource.MustParse("4")}, nil},
+		{"memory exceeded", allocated,
+			v1.ResourceList{"limits.memory": resource.MustParse("4Gi"), "limits.cpu": resource.MustParse("1")},
+			[]string{"limits.memory: 4Gi requested, but only 2Gi of 8Gi available"}},
+		{"limit missing", allocated, v1.ResourceList{"limits.memory": resource.MustParse("1Gi")},
+			[]string{"limits.cpu: the target-group has a quota of 4, so the tenant-target needs a limit as well"}},
+		{"everything exceeded", allocated,
+			v1.ResourceList{"limits.memory": resource.MustParse("3Gi"), "limits.cpu": resource.MustParse("3500m")},
+			[]string{"limits.cpu: 3500m requested, but only 3 of 4 available",
+				"limits.memory: 3Gi requested, but only 2Gi of 8Gi available"}},
+		{"resources without a group quota", allocated,
+			v1.ResourceList{"limits.memory": resource.MustParse("1Gi"), "limits.cpu": resource.MustParse("1"),
+				"pods": resource.MustParse("100")}, nil},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := targetGroupQuotaViolations(quota, test.allocated, test.requested)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("targetGroupQuotaViolations() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -29,9 +29,17 @@ import (
 	"errors"
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/utils/strings/slices"
 	"kufast/objectFactory"
 	"kufast/tools"
+	"strings"
 	"time"
 )
 
@@ -39,7 +47,7 @@ import (
 func CreateTenant(tenantName string, cmd *cobra.Command) error {
 
 	//Configblock
-	clientset, _, err := tools.GetUserClient(cmd)
+	clientset, config, err := tools.GetUserClient(cmd)
 	if err != nil {
 		return err
 	}
@@ -59,6 +67,12 @@ func CreateTenant(tenantName string, cmd *cobra.Command) error {
 		return err
 	}
 
+	//Without any allowed domain, the tenant cannot create ingresses until the administrator allows some
+	err = applyTenantDomainPolicy(config, tenantName, []string{})
+	if err != nil {
+		return err
+	}
+
 	timeout := 600
 	for true {
 		timeout--
@@ -81,7 +95,7 @@ Please ensure it is fully initialized and get its credentials from 'kufast get t
 // DeleteTenant Deletes a tenant. All parameters are drawn from the environment on the command line.
 func DeleteTenant(tenantName string, cmd *cobra.Command) error {
 	//Configblock
-	clientset, _, err := tools.GetUserClient(cmd)
+	clientset, config, err := tools.GetUserClient(cmd)
 	if err != nil {
 		return err
 	}
@@ -101,6 +115,11 @@ func DeleteTenant(tenantName string, cmd *cobra.Command) error {
 		return err
 	}
 
+	err = deleteTenantDomainPolicy(config, tenantName)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -202,6 +221,9 @@ func DeleteTargetFromTenant(targetName string, tenantName string, cmd *cobra.Com
 		} else {
 			delete(tenant.ObjectMeta.Labels, tools.KUFAST_TENANT_GROUPACCESS_LABEL+targetName)
 		}
+		delete(tenant.ObjectMeta.Annotations, tools.KUFAST_TENANT_PLACEMENT_ANNOTATION+targetName)
+		delete(tenant.ObjectMeta.Annotations, tools.KUFAST_TENANT_STORAGECLASS_ANNOTATION+targetName)
+		delete(tenant.ObjectMeta.Annotations, tools.KUFAST_TENANT_SECURITY_ANNOTATION+targetName)
 		_, err = clientset.CoreV1().ServiceAccounts("default").Update(context.TODO(), tenant, metav1.UpdateOptions{})
 		if err != nil {
 			return errors.New(err.Error())
@@ -216,6 +238,19 @@ func DeleteTargetFromTenant(targetName string, tenantName string, cmd *cobra.Com
 
 // AddTargetToTenant adds a new target to a tenant.
 func AddTargetToTenant(cmd *cobra.Command, targetName string, tenantName string) error {
+	placement, _ := cmd.Flags().GetString("placement")
+	if placement != "" && !tools.IsValidPlacementPolicy(placement) {
+		return tools.CreateInvalidPlacementError(placement)
+	}
+	storageClasses, _ := cmd.Flags().GetStringArray("storage-class")
+	security, _ := cmd.Flags().GetString("security")
+	if security == "" {
+		security = tools.KUFAST_DEFAULT_SECURITY_LEVEL
+	}
+	if !tools.IsValidSecurityLevel(security) {
+		return tools.CreateInvalidSecurityLevelError(security)
+	}
+
 	if IsValidTenantTarget(cmd, targetName, tenantName, true) {
 		clientset, _, err := tools.GetUserClient(cmd)
 		if err != nil {
@@ -236,6 +271,23 @@ func AddTargetToTenant(cmd *cobra.Command, targetName string, tenantName string)
 			tenant.ObjectMeta.Labels[tools.KUFAST_TENANT_GROUPACCESS_LABEL+targetName] = "true"
 		}
 
+		if placement != "" {
+			if tenant.ObjectMeta.Annotations == nil {
+				tenant.ObjectMeta.Annotations = map[string]string{}
+			}
+			tenant.ObjectMeta.Annotations[tools.KUFAST_TENANT_PLACEMENT_ANNOTATION+targetName] = placement
+		}
+		if len(storageClasses) > 0 {
+			if tenant.ObjectMeta.Annotations == nil {
+				tenant.ObjectMeta.Annotations = map[string]string{}
+			}
+			tenant.ObjectMeta.Annotations[tools.KUFAST_TENANT_STORAGECLASS_ANNOTATION+targetName] = strings.Join(storageClasses, ",")
+		}
+		if tenant.ObjectMeta.Annotations == nil {
+			tenant.ObjectMeta.Annotations = map[string]string{}
+		}
+		tenant.ObjectMeta.Annotations[tools.KUFAST_TENANT_SECURITY_ANNOTATION+targetName] = security
+
 		// Populate default label if possible
 		if tenant.ObjectMeta.Labels[tools.KUFAST_TENANT_DEFAULT_LABEL] == "" {
 			tenant.ObjectMeta.Labels[tools.KUFAST_TENANT_DEFAULT_LABEL] = targetName
@@ -261,3 +313,276 @@ func GetTenantDefaultTargetNameFromCmd(cmd *cobra.Command) (string, error) {
 
 	return user.ObjectMeta.Labels[tools.KUFAST_TENANT_DEFAULT_LABEL], nil
 }
+
+// GetTenantTargetPlacement returns the placement policy of a tenant-target. Defaults to "none".
+func GetTenantTargetPlacement(cmd *cobra.Command, tenantName string, targetName string) (string, error) {
+
+	tenant, err := GetTenantFromString(cmd, tenantName)
+	if err != nil {
+		return "", err
+	}
+
+	placement := tenant.ObjectMeta.Annotations[tools.KUFAST_TENANT_PLACEMENT_ANNOTATION+targetName]
+	if placement == "" {
+		return "none", nil
+	}
+	return placement, nil
+}
+
+// SetTenantTargetPlacement sets the placement policy of a tenant-target to a new value.
+func SetTenantTargetPlacement(cmd *cobra.Command, tenantName string, targetName string, placement string) error {
+	if !tools.IsValidPlacementPolicy(placement) {
+		return tools.CreateInvalidPlacementError(placement)
+	}
+
+	//Configblock
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	tenant, err := GetTenantFromString(cmd, tenantName)
+	if err != nil {
+		return err
+	}
+
+	if tenant.ObjectMeta.Annotations == nil {
+		tenant.ObjectMeta.Annotations = map[string]string{}
+	}
+	tenant.ObjectMeta.Annotations[tools.KUFAST_TENANT_PLACEMENT_ANNOTATION+targetName] = placement
+	_, err = clientset.CoreV1().ServiceAccounts("default").Update(context.TODO(), tenant, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetTenantDomains returns the domain suffixes a tenant is allowed to use for the hostnames of its ingresses.
+func GetTenantDomains(cmd *cobra.Command, tenantName string) ([]string, error) {
+
+	tenant, err := GetTenantFromString(cmd, tenantName)
+	if err != nil {
+		return nil, err
+	}
+
+	domains := tenant.ObjectMeta.Annotations[tools.KUFAST_TENANT_DOMAINS_ANNOTATION]
+	if domains == "" {
+		return []string{}, nil
+	}
+	return strings.Split(domains, ","), nil
+}
+
+// SetTenantDomains replaces the domain suffixes a tenant is allowed to use for the hostnames of its ingresses.
+// The admission policy of the tenant is updated as well, so the cluster enforces the domains for all ingresses
+// created by the tenant. Existing ingresses are not changed.
+func SetTenantDomains(cmd *cobra.Command, tenantName string, domains []string) error {
+	//Configblock
+	clientset, config, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	tenant, err := GetTenantFromString(cmd, tenantName)
+	if err != nil {
+		return err
+	}
+
+	if tenant.ObjectMeta.Annotations == nil {
+		tenant.ObjectMeta.Annotations = map[string]string{}
+	}
+	if len(domains) == 0 {
+		delete(tenant.ObjectMeta.Annotations, tools.KUFAST_TENANT_DOMAINS_ANNOTATION)
+	} else {
+		tenant.ObjectMeta.Annotations[tools.KUFAST_TENANT_DOMAINS_ANNOTATION] = strings.ToLower(strings.Join(domains, ","))
+	}
+	_, err = clientset.CoreV1().ServiceAccounts("default").Update(context.TODO(), tenant, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return applyTenantDomainPolicy(config, tenantName, domains)
+}
+
+// validatingAdmissionPolicies is the resource of the admission policies, which enforce the domains of the tenants.
+var validatingAdmissionPolicies = schema.GroupVersionResource{Group: "admissionregistration.k8s.io", Version: "v1", Resource: "validatingadmissionpolicies"}
+
+// validatingAdmissionPolicyBindings is the resource of the bindings of the admission policies to the tenant-targets.
+var validatingAdmissionPolicyBindings = schema.GroupVersionResource{Group: "admissionregistration.k8s.io", Version: "v1", Resource: "validatingadmissionpolicybindings"}
+
+// applyTenantDomainPolicy creates the admission policy restricting the hostnames of the ingresses of a tenant to the
+// allowed domains or updates it, if it exists already, e.g. for tenants created by older versions of kufast.
+func applyTenantDomainPolicy(config *rest.Config, tenantName string, domains []string) error {
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	err = applyUnstructuredObject(client.Resource(validatingAdmissionPolicies), objectFactory.NewTenantDomainPolicy(tenantName, domains))
+	if err != nil {
+		return err
+	}
+
+	return applyUnstructuredObject(client.Resource(validatingAdmissionPolicyBindings), objectFactory.NewTenantDomainPolicyBinding(tenantName))
+}
+
+// applyUnstructuredObject creates a cluster-wide object or updates it, if it exists already.
+func applyUnstructuredObject(client dynamic.NamespaceableResourceInterface, object *unstructured.Unstructured) error {
+	existing, err := client.Get(context.TODO(), object.GetName(), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = client.Create(context.TODO(), object, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	object.SetResourceVersion(existing.GetResourceVersion())
+	_, err = client.Update(context.TODO(), object, metav1.UpdateOptions{})
+	return err
+}
+
+// deleteTenantDomainPolicy deletes the admission policy restricting the hostnames of the ingresses of a tenant.
+// Tenants created by older versions of kufast have no policy, which is ignored.
+func deleteTenantDomainPolicy(config *rest.Config, tenantName string) error {
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range []schema.GroupVersionResource{validatingAdmissionPolicyBindings, validatingAdmissionPolicies} {
+		err = client.Resource(resource).Delete(context.TODO(), tenantName+"-domains", metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetTenantTargetSecurityLevel returns the pod security level of a tenant-target. Defaults to "privileged", as
+// tenant-targets created by older versions of kufast are not labeled for the PodSecurity admission.
+func GetTenantTargetSecurityLevel(cmd *cobra.Command, tenantName string, targetName string) (string, error) {
+
+	tenant, err := GetTenantFromString(cmd, tenantName)
+	if err != nil {
+		return "", err
+	}
+
+	security := tenant.ObjectMeta.Annotations[tools.KUFAST_TENANT_SECURITY_ANNOTATION+targetName]
+	if security == "" {
+		return "privileged", nil
+	}
+	return security, nil
+}
+
+// SetTenantTargetSecurityLevel sets the pod security level of a tenant-target to a new value. The labels of the
+// namespace have to be updated separately.
+func SetTenantTargetSecurityLevel(cmd *cobra.Command, tenantName string, targetName string, security string) error {
+	if !tools.IsValidSecurityLevel(security) {
+		return tools.CreateInvalidSecurityLevelError(security)
+	}
+
+	//Configblock
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	tenant, err := GetTenantFromString(cmd, tenantName)
+	if err != nil {
+		return err
+	}
+
+	if tenant.ObjectMeta.Annotations == nil {
+		tenant.ObjectMeta.Annotations = map[string]string{}
+	}
+	tenant.ObjectMeta.Annotations[tools.KUFAST_TENANT_SECURITY_ANNOTATION+targetName] = security
+	_, err = clientset.CoreV1().ServiceAccounts("default").Update(context.TODO(), tenant, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetTenantTargetStorageClasses returns the storage classes a tenant may use for volumes in a tenant-target. An empty
+// list allows all storage classes of the cluster.
+func GetTenantTargetStorageClasses(cmd *cobra.Command, tenantName string, targetName string) ([]string, error) {
+
+	tenant, err := GetTenantFromString(cmd, tenantName)
+	if err != nil {
+		return nil, err
+	}
+
+	storageClasses := tenant.ObjectMeta.Annotations[tools.KUFAST_TENANT_STORAGECLASS_ANNOTATION+targetName]
+	if storageClasses == "" {
+		return []string{}, nil
+	}
+	return strings.Split(storageClasses, ","), nil
+}
+
+// SetTenantTargetStorageClasses replaces the storage classes a tenant may use for volumes in a tenant-target. An empty
+// list allows all storage classes of the cluster. Existing volumes are not changed.
+func SetTenantTargetStorageClasses(cmd *cobra.Command, tenantName string, targetName string, storageClasses []string) error {
+	//Configblock
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	tenant, err := GetTenantFromString(cmd, tenantName)
+	if err != nil {
+		return err
+	}
+
+	if tenant.ObjectMeta.Annotations == nil {
+		tenant.ObjectMeta.Annotations = map[string]string{}
+	}
+	if len(storageClasses) == 0 {
+		delete(tenant.ObjectMeta.Annotations, tools.KUFAST_TENANT_STORAGECLASS_ANNOTATION+targetName)
+	} else {
+		tenant.ObjectMeta.Annotations[tools.KUFAST_TENANT_STORAGECLASS_ANNOTATION+targetName] = strings.Join(storageClasses, ",")
+	}
+	_, err = clientset.CoreV1().ServiceAccounts("default").Update(context.TODO(), tenant, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SetStorageClassQuota restricts the quota of a tenant-target to the allowed storage classes. All other storage classes
+// of the cluster get a quota of zero storage, so Kubernetes rejects volumes using them. An empty storage class stands
+// for the default storage class of the cluster. An empty list allows all storage classes.
+// The quota object is only changed locally and needs to be updated on the cluster.
+func SetStorageClassQuota(cmd *cobra.Command, quota *v1.ResourceQuota, storageClasses []string) error {
+	//Configblock
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	for resourceName := range quota.Spec.Hard {
+		if strings.HasSuffix(string(resourceName), tools.KUFAST_STORAGECLASS_QUOTA_SUFFIX) {
+			delete(quota.Spec.Hard, resourceName)
+		}
+	}
+	if len(storageClasses) == 0 {
+		return nil
+	}
+
+	clusterStorageClasses, err := clientset.StorageV1().StorageClasses().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, storageClass := range clusterStorageClasses.Items {
+		isDefault := storageClass.Annotations["storageclass.kubernetes.io/is-default-class"] == "true"
+		if slices.Contains(storageClasses, storageClass.Name) || (isDefault && slices.Contains(storageClasses, "")) {
+			continue
+		}
+		quota.Spec.Hard[v1.ResourceName(storageClass.Name+tools.KUFAST_STORAGECLASS_QUOTA_SUFFIX)] = resource.MustParse("0")
+	}
+
+	return nil
+}
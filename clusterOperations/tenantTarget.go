@@ -26,11 +26,17 @@ package clusterOperations
 
 import (
 	"context"
+	"errors"
 	"github.com/spf13/cobra"
 	v1 "k8s.io/api/core/v1"
+	n1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/strings/slices"
 	"kufast/objectFactory"
 	"kufast/tools"
+	"strings"
 	"time"
 )
 
@@ -53,7 +59,18 @@ func CreateTenantTarget(tenantName string, targetName string, cmd *cobra.Command
 		cpu, _ := cmd.Flags().GetString("cpu")
 		storage, _ := cmd.Flags().GetString("storage")
 		minStorage, _ := cmd.Flags().GetString("storage-min")
+		ramRequest, _ := cmd.Flags().GetString("memory-request")
+		cpuRequest, _ := cmd.Flags().GetString("cpu-request")
+		maxRatio, _ := cmd.Flags().GetString("max-limit-request-ratio")
 		pods, _ := cmd.Flags().GetString("pods")
+		services, _ := cmd.Flags().GetString("services")
+		nodePorts, _ := cmd.Flags().GetString("nodeports")
+		configMaps, _ := cmd.Flags().GetString("configmaps")
+		volumes, _ := cmd.Flags().GetString("volumes")
+		if !tools.IsValidLimitRequestRatio(maxRatio) {
+			res <- tools.ERROR_INVALID_LIMIT_REQUEST_RATIO
+			return
+		}
 
 		target, err := GetTargetFromTargetName(cmd, targetName, tenantName, true)
 		if err != nil {
@@ -61,6 +78,18 @@ func CreateTenantTarget(tenantName string, targetName string, cmd *cobra.Command
 			return
 		}
 
+		quotaObject := objectFactory.NewResourceQuota(newNamespaceName, ram, cpu, storage, pods, services, nodePorts, configMaps, volumes)
+		objectFactory.SetResourceQuotaRequests(quotaObject, ramRequest, cpuRequest)
+
+		//Ensure the tenant-target fits into the quota of its target-group
+		if target.AccessType == "group" {
+			err = CheckTargetGroupQuota(target.Name, "", quotaObject.Spec.Hard, cmd)
+			if err != nil {
+				res <- err.Error()
+				return
+			}
+		}
+
 		_, err = clientset.CoreV1().Namespaces().Create(context.TODO(), objectFactory.NewNamespace(tenantName, target, cmd), metav1.CreateOptions{})
 		if err != nil {
 			res <- err.Error()
@@ -79,7 +108,7 @@ func CreateTenantTarget(tenantName string, targetName string, cmd *cobra.Command
 			time.Sleep(time.Millisecond * 250)
 		}
 
-		_, err = clientset.CoreV1().ResourceQuotas(newNamespaceName).Create(context.TODO(), objectFactory.NewResourceQuota(newNamespaceName, ram, cpu, storage, pods), metav1.CreateOptions{})
+		_, err = clientset.CoreV1().ResourceQuotas(newNamespaceName).Create(context.TODO(), quotaObject, metav1.CreateOptions{})
 		if err != nil {
 			res <- err.Error()
 			return
@@ -91,13 +120,13 @@ func CreateTenantTarget(tenantName string, targetName string, cmd *cobra.Command
 			return
 		}
 
-		_, err = clientset.CoreV1().LimitRanges(newNamespaceName).Create(context.TODO(), objectFactory.NewLimitRange(newNamespaceName, minStorage, storage), metav1.CreateOptions{})
+		_, err = clientset.CoreV1().LimitRanges(newNamespaceName).Create(context.TODO(), objectFactory.NewLimitRange(newNamespaceName, minStorage, storage, maxRatio), metav1.CreateOptions{})
 		if err != nil {
 			res <- err.Error()
 			return
 		}
 
-		_, err = clientset.NetworkingV1().NetworkPolicies(newNamespaceName).Create(context.TODO(), objectFactory.NewNetworkPolicy(newNamespaceName, tenantName), metav1.CreateOptions{})
+		err = UpdateTenantTargetNetworkPolicies(newNamespaceName, tenantName, QuotaAllowsNodePorts(quotaObject), cmd)
 		if err != nil {
 			res <- err.Error()
 			return
@@ -211,3 +240,131 @@ func GetTenantTargetNameFromCmd(cmd *cobra.Command) (string, error) {
 	}
 	return namespaceName, nil
 }
+
+// GetTargetFromTenantTarget reads the target of a tenant-target from its node-selector annotation.
+func GetTargetFromTenantTarget(tenantTarget *v1.Namespace) (tools.Target, error) {
+	selector := tenantTarget.ObjectMeta.Annotations[tools.KUFAST_NODE_SELECTOR_ANNOTATION]
+
+	if strings.HasPrefix(selector, tools.KUFAST_NODE_HOSTNAME_LABEL+"=") {
+		return tools.Target{
+			Name:       strings.TrimPrefix(selector, tools.KUFAST_NODE_HOSTNAME_LABEL+"="),
+			AccessType: "node",
+		}, nil
+	} else if strings.HasPrefix(selector, tools.KUFAST_NODE_GROUP_LABEL) && strings.HasSuffix(selector, "=true") {
+		return tools.Target{
+			Name:       strings.TrimSuffix(strings.TrimPrefix(selector, tools.KUFAST_NODE_GROUP_LABEL), "=true"),
+			AccessType: "group",
+		}, nil
+	}
+
+	return tools.Target{}, errors.New("No valid kufast node-selector found on tenant-target " + tenantTarget.Name)
+}
+
+// ListAllTenantTargets lists the tenant-targets of all tenants in the cluster.
+func ListAllTenantTargets(cmd *cobra.Command) ([]v1.Namespace, error) {
+
+	//Configblock
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantTargets, err := clientset.CoreV1().Namespaces().List(context.TODO(), metav1.ListOptions{LabelSelector: tools.KUFAST_TENANT_LABEL})
+	if err != nil {
+		return nil, err
+	}
+
+	return tenantTargets.Items, nil
+}
+
+// NotifyTenantTargets writes an event with the given reason and message into each of the tenant-targets. Tenants
+// can read these events with their own credentials.
+func NotifyTenantTargets(tenantTargets []v1.Namespace, reason string, message string, cmd *cobra.Command) error {
+	//Configblock
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	for _, tenantTarget := range tenantTargets {
+		_, err = clientset.CoreV1().Events(tenantTarget.Name).Create(context.TODO(),
+			objectFactory.NewTenantTargetEvent(tenantTarget.Name, reason, message), metav1.CreateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateTenantTargetNetworkPolicies creates the network policies of a tenant-target or updates them to the latest
+// version of kufast. The policy for pods exposed through a NodePort only exists, if the quota of the tenant-target
+// allows NodePorts. Other network policies within the tenant-target are left untouched.
+func UpdateTenantTargetNetworkPolicies(tenantTargetName string, tenantName string, allowNodePorts bool, cmd *cobra.Command) error {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	policies := []*n1.NetworkPolicy{
+		objectFactory.NewNetworkPolicy(tenantTargetName, tenantName),
+	}
+	exposePolicy := objectFactory.NewExposeNetworkPolicy(tenantTargetName, nil)
+	if allowNodePorts {
+		podCIDRs, err := listPodCIDRs(clientset)
+		if err != nil {
+			return err
+		}
+		exposePolicy = objectFactory.NewExposeNetworkPolicy(tenantTargetName, podCIDRs)
+		policies = append(policies, exposePolicy)
+	} else {
+		err = clientset.NetworkingV1().NetworkPolicies(tenantTargetName).Delete(context.TODO(), exposePolicy.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	for _, policy := range policies {
+		existing, err := clientset.NetworkingV1().NetworkPolicies(tenantTargetName).Get(context.TODO(), policy.Name, metav1.GetOptions{})
+		if err != nil {
+			_, err = clientset.NetworkingV1().NetworkPolicies(tenantTargetName).Create(context.TODO(), policy, metav1.CreateOptions{})
+		} else {
+			policy.ResourceVersion = existing.ResourceVersion
+			_, err = clientset.NetworkingV1().NetworkPolicies(tenantTargetName).Update(context.TODO(), policy, metav1.UpdateOptions{})
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// QuotaAllowsNodePorts returns true, if the quota of a tenant-target allows services of type NodePort.
+func QuotaAllowsNodePorts(quota *v1.ResourceQuota) bool {
+	nodePorts, exists := quota.Spec.Hard["services.nodeports"]
+	return !exists || !nodePorts.IsZero()
+}
+
+// listPodCIDRs returns the pod networks of all nodes. Traffic from these networks stems from pods, not from outside of
+// the cluster.
+func listPodCIDRs(clientset *kubernetes.Clientset) ([]string, error) {
+	nodes, err := clientset.CoreV1().Nodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var podCIDRs []string
+	for _, node := range nodes.Items {
+		for _, podCIDR := range append(node.Spec.PodCIDRs, node.Spec.PodCIDR) {
+			if podCIDR != "" && !slices.Contains(podCIDRs, podCIDR) {
+				podCIDRs = append(podCIDRs, podCIDR)
+			}
+		}
+	}
+	if len(podCIDRs) == 0 {
+		return nil, errors.New("The pod networks of the nodes are unknown, so NodePorts cannot be restricted to traffic from outside of the cluster. " +
+			"Please set --nodeports 0.")
+	}
+	return podCIDRs, nil
+}
@@ -0,0 +1,163 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package clusterOperations
+
+import (
+	"context"
+	"errors"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/strings/slices"
+	"kufast/objectFactory"
+	"kufast/tools"
+	"strings"
+	"time"
+)
+
+// CreateVolume creates a new persistent volume within a tenant-target. The storage class must be one of the storage
+// classes allowed for the tenant-target. All parameters are drawn from the environment on the command line.
+func CreateVolume(volumeName string, cmd *cobra.Command) error {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	tenantName, err := GetTenantNameFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+	if err != nil {
+		return err
+	}
+
+	sizeString, _ := cmd.Flags().GetString("size")
+	storageClass, _ := cmd.Flags().GetString("storage-class")
+	accessModeString, _ := cmd.Flags().GetString("access-mode")
+
+	size, err := resource.ParseQuantity(sizeString)
+	if err != nil {
+		return errors.New(sizeString + ": Size has to be a quantity, e.g. 5Gi.")
+	}
+
+	accessMode, err := tools.ParseAccessMode(accessModeString)
+	if err != nil {
+		return err
+	}
+
+	//Ensure the tenant only uses storage classes allowed by the administrator
+	storageClasses, err := GetTenantTargetStorageClasses(cmd, tenantName, tools.GetTargetFromNamespace(namespaceName))
+	if err != nil {
+		return err
+	}
+	if len(storageClasses) > 0 && !slices.Contains(storageClasses, storageClass) {
+		return errors.New(storageClass + ": Storage class has to be one of " + strings.Join(storageClasses, ", ") + ".")
+	}
+
+	volumeObject := objectFactory.NewPersistentVolumeClaim(namespaceName, volumeName, size, storageClass, accessMode)
+
+	_, err = clientset.CoreV1().PersistentVolumeClaims(namespaceName).Create(context.TODO(), volumeObject, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ListTenantVolumes lists all persistent volumes in all tenant-targets of a tenant.
+func ListTenantVolumes(cmd *cobra.Command) ([]v1.PersistentVolumeClaim, error) {
+	clientset, _, err := tools.GetUserClient(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := ListTargetsFromCmd(cmd, false)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantName, err := GetTenantNameFromCmd(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []v1.PersistentVolumeClaim
+	for _, target := range targets {
+		list, err := clientset.CoreV1().PersistentVolumeClaims(tenantName+"-"+target.Name).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, list.Items...)
+	}
+
+	return results, nil
+}
+
+// DeleteVolume deletes an existent persistent volume as an async function. Volumes are only removed, once no pod uses
+// them anymore. The input channel is closed, as soon as the operation completes.
+// All parameters are drawn from the environment on the command line.
+func DeleteVolume(cmd *cobra.Command, volumeName string) <-chan string {
+	res := make(chan string)
+
+	go func() {
+		defer close(res)
+		clientset, _, err := tools.GetUserClient(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		namespaceName, err := GetTenantTargetNameFromCmd(cmd)
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		err = clientset.CoreV1().PersistentVolumeClaims(namespaceName).Delete(context.TODO(), volumeName, metav1.DeleteOptions{})
+		if err != nil {
+			res <- err.Error()
+			return
+		}
+
+		//Check for the volume been deleted from the system
+		timeout := 80
+		for timeout > 0 {
+			timeout--
+			time.Sleep(time.Millisecond * 250)
+			_, err := clientset.CoreV1().PersistentVolumeClaims(namespaceName).Get(context.TODO(), volumeName, metav1.GetOptions{})
+			if err != nil {
+				res <- ""
+				return
+			}
+		}
+		res <- "Operation timeout. Your volume " + volumeName + " still exists, probably because a pod still uses it. " +
+			"It will be removed as soon as it is no longer in use."
+	}()
+
+	return res
+}
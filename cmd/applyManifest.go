@@ -0,0 +1,108 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+)
+
+// applyManifestCmd represents the apply-manifest command
+var applyManifestCmd = &cobra.Command{
+	Use:   "apply-manifest",
+	Short: "Create or update objects from plain Kubernetes manifests.",
+	Long: `Create or update objects from plain Kubernetes manifests within a tenant-target. All objects are placed in the
+tenant-target, regardless of the namespace in the manifest. Kinds you cannot manage with kufast, e.g. roles or namespaces,
+are rejected. Pods get the same labels and node selector as pods created with kufast, so network policies and targets apply.
+Every container needs CPU and memory limits. Services of type LoadBalancer or with external IPs are rejected, use
+'kufast expose' to reach pods from outside. All objects are validated by the cluster against the quota and limits of
+the tenant-target first and are only applied, if all of them are valid. If applying an object fails nevertheless,
+e.g. as another object has been created in the meantime, the objects before it stay applied and are not rolled back.
+Use --dry-run to only validate the objects.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if len(args) != 0 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_CREATE_OBJECTS)
+
+		results, err := clusterOperations.ApplyManifest(cmd)
+		s.Stop()
+
+		if len(results) > 0 {
+			t := table.NewWriter()
+			t.SetOutputMirror(os.Stdout)
+			t.AppendHeader(table.Row{"KIND", "NAME", "RESULT", "MESSAGE"})
+			for _, result := range results {
+				t.AppendRow(table.Row{result.Kind, result.Name, result.Result, result.Message})
+			}
+			t.AppendSeparator()
+			t.Render()
+		}
+
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+		fmt.Println(tools.MESSAGE_DONE)
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	RootCmd.AddCommand(applyManifestCmd)
+
+	applyManifestCmd.Flags().StringArrayP("file", "f", []string{}, "A manifest file with one or several objects. Can be specified multiple times.")
+	_ = applyManifestCmd.MarkFlagRequired("file")
+	applyManifestCmd.Flags().BoolP("dry-run", "", false, "Only validate the objects without applying them.")
+	applyManifestCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	applyManifestCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+
+}
+
+func CreateApplyManifestDocs(linkH func(string) string) {
+	out, err := os.Create("./kufast.wiki/apply-manifest.md")
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		err := out.Close()
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	err = doc.GenMarkdownCustom(applyManifestCmd, out, linkH)
+	if err != nil {
+		panic(err)
+	}
+
+}
@@ -0,0 +1,71 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package create
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+)
+
+// createConfigMapCmd represents the create configmap command
+var createConfigMapCmd = &cobra.Command{
+	Use:   "configmap <name>",
+	Short: "Create a new configmap in a tenant-target",
+	Long: `Creates a new configmap in a tenant-target. A configmap holds non-confidential configuration data as
+key-value pairs. Provide the data as literals with --from-literal KEY=VALUE or from files with --from-file. Pods can use
+a configmap as environment variables (--configmap-env) or as files (--configmap-mount).`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_CREATE_OBJECTS)
+
+		err := clusterOperations.CreateConfigMap(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		s.Stop()
+		fmt.Println(tools.MESSAGE_DONE)
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	createCmd.AddCommand(createConfigMapCmd)
+
+	createConfigMapCmd.Flags().StringArrayP("from-literal", "", []string{}, "A key-value pair in the format KEY=VALUE. Can be specified multiple times.")
+	createConfigMapCmd.Flags().StringArrayP("from-file", "", []string{}, "A file in the format <path> or KEY=<path>. "+
+		"Without a key, the name of the file is used. Can be specified multiple times.")
+
+	createConfigMapCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET+" (Needs to be the same as the pod using it).")
+	createConfigMapCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+
+}
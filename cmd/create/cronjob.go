@@ -0,0 +1,70 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package create
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+)
+
+// createCronJobCmd represents the create cronjob command
+var createCronJobCmd = &cobra.Command{
+	Use:   "cronjob <name> <image>",
+	Short: "Create a new cronjob within a tenant-target",
+	Long: `Creates a new cronjob within a tenant-target. A cronjob starts a job according to a schedule in cron format,
+e.g. --schedule "0 2 * * *" for every night at 2am. A new run is only started, if the previous one has finished.
+You can customize the jobs with the same flags as a job.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 2 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+		s := tools.CreateStandardSpinner(tools.MESSAGE_CREATE_OBJECTS)
+
+		err := clusterOperations.CreateCronJob(args[0], args[1], cmd)
+		s.Stop()
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+		fmt.Println(tools.MESSAGE_DONE)
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	createCmd.AddCommand(createCronJobCmd)
+
+	//Settings for the cronjob
+	createCronJobCmd.Flags().StringP("schedule", "", "", "The schedule of the cronjob in cron format.")
+	tools.AddJobFlags(createCronJobCmd)
+	tools.AddPodFlags(createCronJobCmd, true)
+	tools.AddContainerFlags(createCronJobCmd)
+
+	createCronJobCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	createCronJobCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+	_ = createCronJobCmd.MarkFlagRequired("schedule")
+}
@@ -0,0 +1,78 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package create
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+)
+
+// createDeploymentCmd represents the create deployment command
+var createDeploymentCmd = &cobra.Command{
+	Use:   "deployment <name> <image>",
+	Short: "Create a new deployment within a tenant-target",
+	Long: `Creates a new deployment within a tenant-target. A deployment runs a number of identical pods and replaces
+them, if they fail or their node gets lost. You need to specify the name and the image from which the pods should be created.
+You can customize your deployment with the same flags as a pod. The command waits until all replicas are available.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 2 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+		s := tools.CreateStandardSpinner(tools.MESSAGE_CREATE_OBJECTS)
+
+		err := clusterOperations.CreateDeployment(args[0], args[1], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		err = clusterOperations.WaitForDeploymentRollout(args[0], cmd, func(status string) {
+			s.Lock()
+			s.Suffix = status
+			s.Unlock()
+		})
+		s.Stop()
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+		fmt.Println(tools.MESSAGE_DONE)
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	createCmd.AddCommand(createDeploymentCmd)
+
+	//Settings for the deployment
+	createDeploymentCmd.Flags().Int32P("replicas", "r", 1, "The number of pods the deployment should run.")
+	tools.AddPodFlags(createDeploymentCmd, true)
+	tools.AddContainerFlags(createDeploymentCmd)
+
+	createDeploymentCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	createDeploymentCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
@@ -0,0 +1,69 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package create
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+)
+
+// createIngressCmd represents the create ingress command
+var createIngressCmd = &cobra.Command{
+	Use:   "ingress <name> <hostname>",
+	Short: "Route HTTP requests for a hostname to a service",
+	Long: `Creates a new ingress within a tenant-target. An ingress routes all HTTP requests for a hostname and path
+to a service, which you can create with 'kufast expose'. The hostname must be within one of the domains your
+administrator allowed for your tenant. To serve the hostname via HTTPS, provide a TLS secret in the same tenant-target.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 2 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+		s := tools.CreateStandardSpinner(tools.MESSAGE_CREATE_OBJECTS)
+
+		err := clusterOperations.CreateIngress(args[0], args[1], cmd)
+		s.Stop()
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+		fmt.Println(tools.MESSAGE_DONE)
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	createCmd.AddCommand(createIngressCmd)
+
+	createIngressCmd.Flags().StringP("service", "", "", "The service requests are routed to. Defaults to the name of the ingress.")
+	createIngressCmd.Flags().Int32P("port", "p", 0, "The port of the service requests are routed to. Defaults to the first port of the service.")
+	createIngressCmd.Flags().StringP("path", "", "/", "Only requests with this path prefix are routed to the service.")
+	createIngressCmd.Flags().StringP("tls-secret", "", "", "The name of a TLS secret holding the certificate for the hostname.")
+	createIngressCmd.Flags().StringP("class", "", "", "The ingress class to use. Defaults to the default ingress class of the cluster.")
+
+	createIngressCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	createIngressCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
@@ -29,30 +29,65 @@ import (
 	"github.com/spf13/cobra"
 	"kufast/clusterOperations"
 	"kufast/tools"
+	"strings"
 )
 
 // createPodCmd represents the create pod command
 var createPodCmd = &cobra.Command{
-	Use:   "pod <name> <image>",
+	Use:   "pod <name> [image]",
 	Short: "Create a new pod within a tenant-target",
 	Long: `Creates a new pod within a tenant-target. A pod is like a shell for a container in Kuebrnetes. 
 You need to specify the name and the image from which the pod should be created.
-You can customize your deployment with the flags below or by using the interactive mode.`,
+You can customize your deployment with the flags below or by using the interactive mode.
+With --profile, the settings of a profile are used. The image can be omitted then and flags override the profile.
+The command waits until the pod is running and shows the reason and the last log lines, if it cannot be started.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		isInteractive, _ := cmd.Flags().GetBool("interactive")
 		if isInteractive {
 			args = createPodInteractive(cmd)
 		}
+		profileName, _ := cmd.Flags().GetString("profile")
+		if profileName != "" {
+			profile, invalidProfiles, err := clusterOperations.GetProfile(profileName, cmd)
+			if err != nil {
+				tools.HandleError(err, cmd)
+			}
+			for _, invalidProfile := range invalidProfiles {
+				fmt.Println("Warning: Skipped shared profile " + invalidProfile)
+			}
+			err = tools.ApplyProfileToFlags(cmd, *profile)
+			if err != nil {
+				tools.HandleError(err, cmd)
+			}
+			if len(args) == 1 && profile.Image != "" {
+				args = append(args, profile.Image)
+			}
+		}
 		if len(args) != 2 {
 			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
 		}
 		s := tools.CreateStandardSpinner(tools.MESSAGE_CREATE_OBJECTS)
 
 		res := clusterOperations.CreatePod(cmd, args)
-		err := <-res
+		resErr := <-res
+		if resErr != "" {
+			s.Stop()
+			tools.HandleError(errors.New(resErr), cmd)
+		}
+		//Warnings are sent after the result
+		historyWarning := <-res
+
+		err := clusterOperations.WaitForPodStartup(args[0], cmd, func(status string) {
+			s.Lock()
+			s.Suffix = status
+			s.Unlock()
+		})
 		s.Stop()
-		if err != "" {
-			tools.HandleError(errors.New(err), cmd)
+		if historyWarning != "" {
+			fmt.Println(historyWarning)
+		}
+		if err != nil {
+			tools.HandleError(err, cmd)
 		}
 		fmt.Println(tools.MESSAGE_DONE)
 
@@ -78,10 +113,20 @@ func createPodInteractive(cmd *cobra.Command) []string {
 	_ = cmd.Flags().Set("storage", limitStorage)
 	target := tools.GetDialogAnswer("Please select the tenant-target for your request. Leave empty for default. You can use 'kufast list tenant-targets' to get a list.")
 	_ = cmd.Flags().Set("target", target)
-	keepAlive := tools.GetDialogAnswer("Should we restart the container for you, if it completes? (y/N)")
-	if keepAlive == "y" {
-		_ = cmd.Flags().Set("keep-alive", "true")
+	restart := tools.GetDialogAnswer("When should we restart the container for you? (Always, OnFailure or Never)")
+	_ = cmd.Flags().Set("restart", restart)
+	workdir := tools.GetDialogAnswer("Please enter the working directory of the container. Leave empty to use the one of the image.")
+	_ = cmd.Flags().Set("workdir", workdir)
+	containerArgs := tools.GetDialogAnswer("Please enter the arguments passed to the command of the container, separated by spaces. Leave empty to use the ones of the image.")
+	for _, containerArg := range strings.Fields(containerArgs) {
+		_ = cmd.Flags().Set("args", containerArg)
+	}
+	runAsUser := tools.GetDialogAnswer("Please enter the user ID the container should run with. Leave empty to use the one of the image.")
+	if runAsUser != "" {
+		_ = cmd.Flags().Set("run-as-user", runAsUser)
 	}
+	pullPolicy := tools.GetDialogAnswer("When should the image be pulled? (Always, IfNotPresent or Never) Leave empty for the default.")
+	_ = cmd.Flags().Set("pull-policy", pullPolicy)
 	deploySecret := tools.GetDialogAnswer("If your deployment needs a deploy secret, please enter it now. Leave empty, if no deploy-secret is required. Please note that the deploy-secret must be in the same tenant-target.")
 	_ = cmd.Flags().Set("deploy-secret", deploySecret)
 
@@ -93,15 +138,13 @@ func init() {
 	createCmd.AddCommand(createPodCmd)
 
 	//Settings for the pod
-	createPodCmd.Flags().BoolP("keep-alive", "", false, "Pod will be restarted upon termination.")
-	createPodCmd.Flags().StringP("memory", "", "500Mi", "The amount of RAM the pod can use")
-	createPodCmd.Flags().StringP("cpu", "", "500m", "The amount of CPU the pod can use")
-	createPodCmd.Flags().StringP("storage", "", "1Gi", "The amount of storage the pod can use")
-	createPodCmd.Flags().StringP("deploy-secret", "d", "", "The name of the deployment secret to deploy this container. This secret will be used to pull the image.")
-	createPodCmd.Flags().StringArrayP("secrets", "s", []string{}, "List of secret names to be introduced in the container "+
-		"as environment variables. The variable name will equal the name of the secret. Can be specified multiple times.")
-	createPodCmd.Flags().Int32SliceP("port", "p", []int32{}, "A port the pod should expose. Can be specified multiple times.")
-	createPodCmd.Flags().StringArrayP("cmd", "", []string{}, "An initial command to be issued at pod start. Required by a few containers.")
+	createPodCmd.Flags().BoolP("keep-alive", "", false, "Pod will be restarted upon termination. Short form of --restart Always.")
+	createPodCmd.Flags().StringP("restart", "", "", "When the containers of the pod are restarted, "+
+		"either Always, OnFailure or Never. Defaults to Always.")
+	createPodCmd.Flags().StringP("profile", "", "", "A profile to take the settings of the pod from. "+
+		"Use 'kufast list profiles' to get a list.")
+	tools.AddPodFlags(createPodCmd, true)
+	tools.AddContainerFlags(createPodCmd)
 
 	createPodCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
 	createPodCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
@@ -0,0 +1,74 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package create
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+)
+
+// createProfileCmd represents the create profile command
+var createProfileCmd = &cobra.Command{
+	Use:   "profile <name>",
+	Short: "Save the settings of a pod as reusable profile",
+	Long: `Saves the settings of a pod as profile, which can be used with 'kufast create pod <name> --profile <profile>'.
+The profile is created from the pod flags you provide or from an existing pod with --from-pod.
+Profiles are shared with everyone using the tenant-target. With --local, the profile is only stored on your machine.
+An existing profile with the same name is replaced.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_CREATE_OBJECTS)
+
+		err := clusterOperations.CreateProfile(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		s.Stop()
+		fmt.Println(tools.MESSAGE_DONE)
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	createCmd.AddCommand(createProfileCmd)
+
+	//Settings for the profile
+	createProfileCmd.Flags().StringP("from-pod", "", "", "An existing pod to take the settings from. Pod flags are ignored.")
+	createProfileCmd.Flags().BoolP("local", "", false, "Store the profile on your machine instead of the tenant-target.")
+	createProfileCmd.Flags().StringP("image", "", "", "The image of pods created with the profile.")
+	createProfileCmd.Flags().StringP("restart", "", "", "When the containers are restarted, either Always, OnFailure or Never.")
+	tools.AddPodFlags(createProfileCmd, false)
+
+	createProfileCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	createProfileCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
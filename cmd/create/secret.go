@@ -35,9 +35,10 @@ import (
 var createSecretCmd = &cobra.Command{
 	Use:   "secret name",
 	Short: "Create a new environment secret in this namespace",
-	Long: `This command creates a new user and adds him to a namespace. You can select the namespace of the user.
-Upon completion, the command yields the users credentials. This command will fail, if you do not have admin rights 
-on the cluster.`,
+	Long: `This command creates a new secret in a tenant-target. By default, you are asked for the secret, which is
+stored under a single key and introduced into pods as environment variable named after the secret (--secrets).
+With --from-literal and --from-file, a secret with several keys is created instead. Its keys can be introduced into pods
+as environment variables with --secret-env or the whole secret can be mounted as files with --secret-mount.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		isInteractive, _ := cmd.Flags().GetBool("interactive")
 		if isInteractive {
@@ -48,6 +49,18 @@ on the cluster.`,
 			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
 		}
 
+		//Secrets with several keys are read from the command line, single secrets are entered by the user
+		if cmd.Flags().Changed("from-literal") || cmd.Flags().Changed("from-file") {
+			s := tools.CreateStandardSpinner(tools.MESSAGE_CREATE_OBJECTS)
+			err := clusterOperations.CreateMultiKeySecret(args[0], cmd)
+			s.Stop()
+			if err != nil {
+				tools.HandleError(err, cmd)
+			}
+			fmt.Println(tools.MESSAGE_DONE)
+			return
+		}
+
 		//Get the secret
 		secretData := tools.GetPasswordAnswer("Enter your secret here:")
 
@@ -80,6 +93,9 @@ func createSecretInteractive(cmd *cobra.Command) []string {
 func init() {
 	createCmd.AddCommand(createSecretCmd)
 
+	createSecretCmd.Flags().StringArrayP("from-literal", "", []string{}, "A key-value pair in the format KEY=VALUE. Can be specified multiple times.")
+	createSecretCmd.Flags().StringArrayP("from-file", "", []string{}, "A file in the format <path> or KEY=<path>. "+
+		"Without a key, the name of the file is used. Can be specified multiple times.")
 	createSecretCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET+" (Needs to be the same as the pod using it).")
 	createSecretCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
 
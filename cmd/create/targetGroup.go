@@ -37,7 +37,8 @@ var createTargetGroupCmd = &cobra.Command{
 	Short: "Create a target-group within the cluster",
 	Long: `This command creates a new target-group and assigns it to the specified nodes.
 Target-groups can be used to define a tenant-target that can deploy to a group of nodes,
-instead of a single node.`,
+instead of a single node. Optionally, a quota for the whole group can be set. The quotas of all
+tenant-targets on the group must fit into it.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		isInteractive, _ := cmd.Flags().GetBool("interactive")
 		if isInteractive {
@@ -55,6 +56,12 @@ instead of a single node.`,
 			tools.HandleError(err, cmd)
 		}
 
+		err = clusterOperations.SetTargetGroupQuota(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
 		s.Stop()
 		fmt.Println(tools.MESSAGE_DONE)
 
@@ -80,4 +87,10 @@ func createTargetGroupInteractive() []string {
 func init() {
 	createCmd.AddCommand(createTargetGroupCmd)
 
+	//Optional quota for the whole group
+	createTargetGroupCmd.Flags().StringP("memory", "", "", "Limit the RAM all tenant-targets on this group can allocate")
+	createTargetGroupCmd.Flags().StringP("cpu", "", "", "Limit the CPU all tenant-targets on this group can allocate")
+	createTargetGroupCmd.Flags().StringP("storage", "", "", "Limit the storage all tenant-targets on this group can allocate")
+	createTargetGroupCmd.Flags().StringP("pods", "", "", "Limit the number of pods all tenant-targets on this group can allocate")
+
 }
@@ -136,9 +136,23 @@ func init() {
 
 	createTenantCmd.Flags().StringP("memory", "", "1Gi", "Limit the RAM usage for the tenant-target(s)")
 	createTenantCmd.Flags().StringP("cpu", "", "500m", "Limit the CPU usage for the tenant-target(s)")
+	createTenantCmd.Flags().StringP("memory-request", "", "", "Limit the total RAM requests for the tenant-target(s). Defaults to the value of --memory.")
+	createTenantCmd.Flags().StringP("cpu-request", "", "", "Limit the total CPU requests for the tenant-target(s). Defaults to the value of --cpu.")
+	createTenantCmd.Flags().StringP("max-limit-request-ratio", "", "", "Maximum ratio between the limits and requests of CPU and memory "+
+		"of a container in the tenant-target(s), e.g. 2. No restriction, if not set.")
 	createTenantCmd.Flags().StringP("storage", "", "10Gi", "Limit the total storage for the tenant-target(s)")
 	createTenantCmd.Flags().StringP("storage-min", "", "1Gi", "Set the amount of storage, each pod must consume")
 	createTenantCmd.Flags().StringP("pods", "", "1", "Limit the Number of pods that can be created for the tenant-target(s)")
+	createTenantCmd.Flags().StringP("services", "", "5", "Limit the number of services that can be created for the tenant-target(s)")
+	createTenantCmd.Flags().StringP("nodeports", "", "0", "Limit the number of services of the tenant-target(s) that are reachable from outside through a NodePort")
+	createTenantCmd.Flags().StringP("configmaps", "", "20", "Limit the number of configmaps that can be created for the tenant-target(s)")
+	createTenantCmd.Flags().StringP("volumes", "", "5", "Limit the number of volumes that can be created for the tenant-target(s)")
+	createTenantCmd.Flags().StringArrayP("storage-class", "", []string{}, "A storage class the tenant may use for volumes. "+
+		"All storage classes are allowed, if none is given. Can be specified multiple times.")
+	createTenantCmd.Flags().StringP("placement", "", "none", "Placement policy for pods on group targets. "+
+		"Either spread (distribute pods evenly over the nodes), pack (place pods on as few nodes as possible) or none.")
+	createTenantCmd.Flags().StringP("security", "", "restricted", "Pod security level of the tenant-target(s). "+
+		"Either restricted (pods run as non-root without privileges), baseline (prevents known privilege escalations) or privileged.")
 
 	createTenantCmd.Flags().StringArrayP("target", "", nil, "Deployment target for the tenant. Can be specified multiple times.")
 
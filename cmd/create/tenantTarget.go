@@ -122,6 +122,12 @@ func createTenantTargetInteractive(cmd *cobra.Command) []string {
 	_ = cmd.Flags().Set("min-storage", limitMinStorage)
 	limitPods := tools.GetDialogAnswer("Which pod limit do you want to set for the tenant-target(s)? (e.g. 100Mi, 5Gi)")
 	_ = cmd.Flags().Set("pods", limitPods)
+	placement := tools.GetDialogAnswer("Which placement policy do you want to set for the tenant-target(s)? (spread, pack or none)")
+	_ = cmd.Flags().Set("placement", placement)
+	security := tools.GetDialogAnswer("Which security level do you want to set for the tenant-target(s)? (restricted, baseline or privileged, leave empty for restricted)")
+	if security != "" {
+		_ = cmd.Flags().Set("security", security)
+	}
 
 	return args
 }
@@ -133,9 +139,23 @@ func init() {
 	//Set minimum values
 	createTenantTargetCmd.Flags().StringP("memory", "", "1Gi", "Limit the RAM usage for the tenant-target(s)")
 	createTenantTargetCmd.Flags().StringP("cpu", "", "500m", "Limit the CPU usage for the tenant-target(s)")
+	createTenantTargetCmd.Flags().StringP("memory-request", "", "", "Limit the total RAM requests for the tenant-target(s). Defaults to the value of --memory.")
+	createTenantTargetCmd.Flags().StringP("cpu-request", "", "", "Limit the total CPU requests for the tenant-target(s). Defaults to the value of --cpu.")
+	createTenantTargetCmd.Flags().StringP("max-limit-request-ratio", "", "", "Maximum ratio between the limits and requests of CPU and memory "+
+		"of a container in the tenant-target(s), e.g. 2. No restriction, if not set.")
 	createTenantTargetCmd.Flags().StringP("pods", "", "1", "Limit the Number of pods that can be created for the tenant-target(s)")
+	createTenantTargetCmd.Flags().StringP("services", "", "5", "Limit the number of services that can be created for the tenant-target(s)")
+	createTenantTargetCmd.Flags().StringP("nodeports", "", "0", "Limit the number of services of the tenant-target(s) that are reachable from outside through a NodePort")
+	createTenantTargetCmd.Flags().StringP("configmaps", "", "20", "Limit the number of configmaps that can be created for the tenant-target(s)")
+	createTenantTargetCmd.Flags().StringP("volumes", "", "5", "Limit the number of volumes that can be created for the tenant-target(s)")
+	createTenantTargetCmd.Flags().StringArrayP("storage-class", "", []string{}, "A storage class the tenant may use for volumes. "+
+		"All storage classes are allowed, if none is given. Can be specified multiple times.")
 	createTenantTargetCmd.Flags().StringP("storage", "", "10Gi", "Limit the total storage for the tenant-target(s)")
 	createTenantTargetCmd.Flags().StringP("storage-min", "", "1Gi", "Set the amount of storage, each pod must consume")
+	createTenantTargetCmd.Flags().StringP("placement", "", "none", "Placement policy for pods on group targets. "+
+		"Either spread (distribute pods evenly over the nodes), pack (place pods on as few nodes as possible) or none.")
+	createTenantTargetCmd.Flags().StringP("security", "", "restricted", "Pod security level of the tenant-target(s). "+
+		"Either restricted (pods run as non-root without privileges), baseline (prevents known privilege escalations) or privileged.")
 
 	//Tenant for the operation must be always specified
 	createTenantTargetCmd.Flags().StringP("tenant", "t", "", "The tenant for the tenant-target(s).")
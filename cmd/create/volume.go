@@ -0,0 +1,70 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package create
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+)
+
+// createVolumeCmd represents the create volume command
+var createVolumeCmd = &cobra.Command{
+	Use:   "volume <name>",
+	Short: "Create a new persistent volume in a tenant-target",
+	Long: `Creates a new persistent volume in a tenant-target. Data stored on a volume is kept, when a pod restarts or
+is deleted. Mount a volume into a pod with --volume <name>:<path>. The size of all volumes counts towards the storage
+limit of the tenant-target. Your administrator may restrict the storage classes you can use.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_CREATE_OBJECTS)
+
+		err := clusterOperations.CreateVolume(args[0], cmd)
+		s.Stop()
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+		fmt.Println(tools.MESSAGE_DONE)
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	createCmd.AddCommand(createVolumeCmd)
+
+	createVolumeCmd.Flags().StringP("size", "", "", "The size of the volume, e.g. 5Gi")
+	createVolumeCmd.Flags().StringP("storage-class", "", "", "The storage class of the volume. Defaults to the default storage class of the cluster.")
+	createVolumeCmd.Flags().StringP("access-mode", "", "rwo", "Either rwo (read-write by pods on one node), rox (read-only by many pods) "+
+		"or rwx (read-write by many pods).")
+
+	createVolumeCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET+" (Needs to be the same as the pod using it).")
+	createVolumeCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+	_ = createVolumeCmd.MarkFlagRequired("size")
+}
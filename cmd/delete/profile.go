@@ -0,0 +1,68 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package delete
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+)
+
+// deleteProfileCmd represents the delete profile command
+var deleteProfileCmd = &cobra.Command{
+	Use:   "profile <profile>..",
+	Short: "Deletes a pod profile.",
+	Long: `Deletes a pod profile from the tenant-target or, with --local, from your machine.
+Pods created with the profile are not affected.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		//Check that at least one profile has been provided
+		if len(args) < 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_DELETE_OBJECTS)
+
+		for _, profile := range args {
+			err := clusterOperations.DeleteProfile(profile, cmd)
+			if err != nil {
+				s.Stop()
+				tools.HandleError(err, cmd)
+			}
+		}
+
+		s.Stop()
+		fmt.Println(tools.MESSAGE_DONE)
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	deleteCmd.AddCommand(deleteProfileCmd)
+
+	deleteProfileCmd.Flags().BoolP("local", "", false, "Delete the profile from your machine instead of the tenant-target.")
+	deleteProfileCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	deleteProfileCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
@@ -36,31 +36,76 @@ var deleteTargetGroupCmd = &cobra.Command{
 	Use:   "target-group <target-group>..",
 	Short: "Deletes a target-group from the cluster.",
 	Long: `Deletes a target-group from the cluster. This operation can only be executed by a cluster admin.
-Please use with care! Tenant-targets pointing to these target-groups remain intact, but cannot deploy new pods.`,
+Before the deletion, the impact is shown: the tenants with access to the group, the tenant-targets deploying to it
+and their pods, which cannot be scheduled anymore. The deletion must be confirmed, unless --force is set.
+Please use with care! Tenant-targets pointing to these target-groups remain intact, but cannot deploy new pods.
+Use --revoke-access to remove the access to the group from all affected tenants as well.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		//Check that exactly one arg has been provided (the namespace)
+		//Check that at least one target-group has been provided
 		if len(args) < 1 {
 			tools.HandleError(errors.New("Too few arguments provided."), cmd)
 		}
 
+		force, _ := cmd.Flags().GetBool("force")
+		revokeAccess, _ := cmd.Flags().GetBool("revoke-access")
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_GET_OBJECTS)
+
+		var impacts []tools.TargetGroupImpact
+		for _, group := range args {
+			if !clusterOperations.IsValidTarget(cmd, group, true) {
+				s.Stop()
+				tools.HandleError(errors.New("Target-group "+group+" does not exist."), cmd)
+			}
+
+			impact, err := clusterOperations.GetTargetGroupImpact(group, nil, cmd)
+			if err != nil {
+				s.Stop()
+				tools.HandleError(err, cmd)
+			}
+			impacts = append(impacts, impact)
+		}
+
+		s.Stop()
+		for i, group := range args {
+			tools.RenderTargetGroupImpact(group, impacts[i])
+		}
+
 		//Ensure user knows what he does
-		answer := tools.GetDialogAnswer("Targetgroup will be deleted! Spaces with that target group remain intact but are unable to deploy! Continue (yes/No)")
-		if answer == "yes" {
+		if !force {
+			answer := tools.GetDialogAnswer("Targetgroup will be deleted! Spaces with that target group remain intact but are unable to deploy! Continue (yes/No)")
+			if answer != "yes" {
+				return
+			}
+		}
 
-			s := tools.CreateStandardSpinner(tools.MESSAGE_DELETE_OBJECTS)
+		s = tools.CreateStandardSpinner(tools.MESSAGE_DELETE_OBJECTS)
 
-			for _, group := range args {
-				err := clusterOperations.DeleteTargetGroupFromNodes(group, cmd)
-				if err != nil {
-					s.Stop()
-					tools.HandleError(err, cmd)
+		for i, group := range args {
+			if revokeAccess {
+				for _, tenant := range impacts[i].Tenants {
+					err := clusterOperations.DeleteTargetFromTenant(group, tenant, cmd)
+					if err != nil {
+						s.Stop()
+						tools.HandleError(err, cmd)
+					}
 				}
 			}
 
-			s.Stop()
-			fmt.Println(tools.MESSAGE_DONE)
-
+			err := clusterOperations.DeleteTargetGroupFromNodes(group, cmd)
+			if err != nil {
+				s.Stop()
+				tools.HandleError(err, cmd)
+			}
+			err = clusterOperations.DeleteTargetGroupQuota(group, cmd)
+			if err != nil {
+				s.Stop()
+				tools.HandleError(err, cmd)
+			}
 		}
+
+		s.Stop()
+		fmt.Println(tools.MESSAGE_DONE)
 	},
 }
 
@@ -68,6 +113,8 @@ Please use with care! Tenant-targets pointing to these target-groups remain inta
 func init() {
 	deleteCmd.AddCommand(deleteTargetGroupCmd)
 
+	deleteTargetGroupCmd.Flags().BoolP("force", "f", false, "Delete the target-group without confirmation.")
+	deleteTargetGroupCmd.Flags().BoolP("revoke-access", "", false, "Remove the access to the target-group from all affected tenants.")
 	deleteTargetGroupCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
 	deleteTargetGroupCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
 
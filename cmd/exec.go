@@ -73,12 +73,14 @@ command line type "exit".`,
 
 		req := clientset.CoreV1().RESTClient().Post().Resource("pods").Name(args[0]).
 			Namespace(namespaceName).SubResource("exec")
+		containerName, _ := cmd.Flags().GetString("container")
 		option := &v1.PodExecOptions{
-			Command: comm,
-			Stdin:   true,
-			Stdout:  true,
-			Stderr:  true,
-			TTY:     true,
+			Container: containerName,
+			Command:   comm,
+			Stdin:     true,
+			Stdout:    true,
+			Stderr:    true,
+			TTY:       true,
 		}
 		req.VersionedParams(
 			option,
@@ -106,6 +108,7 @@ func init() {
 
 	// Here you will define your flags and configuration settings.
 	execCmd.Flags().StringP("command", "c", "/bin/sh", "Set the command for this operation")
+	execCmd.Flags().StringP("container", "", "", "The container to exec into. Defaults to the main container of the pod.")
 	execCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
 	execCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
 
@@ -0,0 +1,67 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package explain
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+)
+
+// explainPodCmd represents the explain pod command
+var explainPodCmd = &cobra.Command{
+	Use:   "pod <name>",
+	Short: "Explain why a pod is pending or failing",
+	Long: `Inspects a pod, its events, the quota and limit range of its tenant-target and the nodes of its target
+and explains in plain language, why the pod is pending or failing, e.g. "Quota memory exhausted: 900Mi of 1Gi used".
+The capacity of the nodes is only taken into account, if your credentials allow to read the nodes.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+		s := tools.CreateStandardSpinner(tools.MESSAGE_GET_OBJECTS)
+
+		diagnosis, err := clusterOperations.ExplainPod(args[0], cmd)
+		s.Stop()
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+
+		fmt.Println("Diagnosis of pod " + args[0] + ":")
+		for _, finding := range diagnosis {
+			fmt.Println("- " + finding)
+		}
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	explainCmd.AddCommand(explainPodCmd)
+
+	explainPodCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	explainPodCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
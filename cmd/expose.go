@@ -0,0 +1,103 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+)
+
+// exposeCmd represents the expose command
+var exposeCmd = &cobra.Command{
+	Use:   "expose <pod|deployment>",
+	Short: "Make the ports of a pod or deployment reachable through a service.",
+	Long: `Make the ports of a pod or deployment reachable through a service. By default, a service of type clusterip
+is created, which is reachable under a stable DNS name from all of your tenant-targets. With --type nodeport the service
+is also reachable on a port of every node from outside of the cluster, if your tenant-target allows NodePorts.
+Exposing a deployment through a NodePort restarts its pods. If no ports are given, all ports of the workload are exposed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		//Check that exactly one arg has been provided (the workload)
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_CREATE_OBJECTS)
+
+		service, err := clusterOperations.ExposeWorkload(args[0], cmd)
+		s.Stop()
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"ATTRIBUTE", "VALUE"})
+		t.AppendRow(table.Row{"Service", service.Name})
+		t.AppendRow(table.Row{"Type", service.Spec.Type})
+		t.AppendRow(table.Row{"Addresses", tools.FormatServiceAddresses(*service)})
+		t.AppendSeparator()
+		t.Render()
+
+		fmt.Println(tools.MESSAGE_DONE)
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	RootCmd.AddCommand(exposeCmd)
+
+	exposeCmd.Flags().StringP("type", "", "clusterip", "The type of the service. Either clusterip or nodeport.")
+	exposeCmd.Flags().StringP("name", "", "", "The name of the service. Defaults to the name of the workload.")
+	exposeCmd.Flags().Int32SliceP("port", "p", []int32{}, "A port that should be exposed. Can be specified multiple times.")
+	exposeCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	exposeCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+
+}
+
+func CreateExposeDocs(linkH func(string) string) {
+	out, err := os.Create("./kufast.wiki/expose.md")
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		err := out.Close()
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	err = doc.GenMarkdownCustom(exposeCmd, out, linkH)
+	if err != nil {
+		panic(err)
+	}
+
+}
@@ -0,0 +1,85 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package get
+
+import (
+	"errors"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+	"sort"
+)
+
+// getConfigMapCmd represents the get configmap command
+var getConfigMapCmd = &cobra.Command{
+	Use:   "configmap <configmap>",
+	Short: "Gain information about a configmap.",
+	Long:  `Gain information about a configmap. Output includes name, tenant-target and all keys with their values.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_GET_OBJECTS)
+
+		configMap, err := clusterOperations.GetConfigMap(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		var keys []string
+		for key := range configMap.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"ATTRIBUTE", "VALUE"})
+		t.AppendRow(table.Row{"Name", configMap.Name})
+		t.AppendRow(table.Row{"Namespace", configMap.Namespace})
+		t.AppendSeparator()
+		for _, key := range keys {
+			t.AppendRow(table.Row{key, configMap.Data[key]})
+		}
+
+		s.Stop()
+
+		t.AppendSeparator()
+		t.Render()
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	getCmd.AddCommand(getConfigMapCmd)
+
+	getConfigMapCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	getConfigMapCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
@@ -0,0 +1,113 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package get
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+)
+
+// getCronJobCmd represents the get cronjob command
+var getCronJobCmd = &cobra.Command{
+	Use:   "cronjob <cronjob>",
+	Short: "Gain information about a cronjob.",
+	Long: `Gain information about a cronjob. Output includes name, tenant-target, schedule, the time of the last run
+and last successful run as well as the status of all runs still kept in the cluster.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_GET_OBJECTS)
+
+		cronJob, err := clusterOperations.GetCronJob(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		jobs, err := clusterOperations.ListTenantJobs(cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		lastRun, lastSuccess := "", ""
+		if cronJob.Status.LastScheduleTime != nil {
+			lastRun = cronJob.Status.LastScheduleTime.String()
+		}
+		if cronJob.Status.LastSuccessfulTime != nil {
+			lastSuccess = cronJob.Status.LastSuccessfulTime.String()
+		}
+		suspended := cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend
+
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"ATTRIBUTE", "VALUE"})
+		t.AppendRow(table.Row{"Name", cronJob.Name})
+		t.AppendRow(table.Row{"Tenant-Target", cronJob.Namespace})
+		t.AppendRow(table.Row{"Schedule", cronJob.Spec.Schedule})
+		t.AppendRow(table.Row{"Suspended", suspended})
+		t.AppendRow(table.Row{"Active Runs", len(cronJob.Status.Active)})
+		t.AppendSeparator()
+		t.AppendRow(table.Row{"Last Run", lastRun})
+		t.AppendRow(table.Row{"Last Successful Run", lastSuccess})
+		t.AppendSeparator()
+		t.AppendRow(table.Row{"Deployed Image", cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Image})
+		t.AppendSeparator()
+
+		//build run table
+		r := table.NewWriter()
+		r.SetOutputMirror(os.Stdout)
+		r.AppendHeader(table.Row{"JOB", "STATUS", "STARTED"})
+		for _, job := range clusterOperations.GetCronJobRuns(*cronJob, jobs) {
+			started := ""
+			if job.Status.StartTime != nil {
+				started = job.Status.StartTime.String()
+			}
+			r.AppendRow(table.Row{job.Name, tools.FormatJobStatus(job), started})
+		}
+		r.AppendSeparator()
+
+		s.Stop()
+		t.Render()
+		fmt.Println("\n" + "Runs:")
+		r.Render()
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	getCmd.AddCommand(getCronJobCmd)
+
+	getCronJobCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	getCronJobCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
@@ -0,0 +1,128 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package get
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+	"strconv"
+)
+
+// getDeploymentCmd represents the get deployment command
+var getDeploymentCmd = &cobra.Command{
+	Use:   "deployment <deployment>",
+	Short: "Gain information about a deployment.",
+	Long: `Gain information about a deployment. Output includes name, tenant-target, replicas, rollout status, limits
+and image of the pods, the addresses of its services as well as the pods belonging to the deployment.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_GET_OBJECTS)
+
+		deployment, err := clusterOperations.GetDeployment(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		pods, err := clusterOperations.ListTenantPods(cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		services, err := clusterOperations.ListPodServices(deployment.Namespace, deployment.Spec.Template.Labels, cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		rollout := "In progress"
+		if clusterOperations.IsDeploymentRolledOut(deployment) {
+			rollout = "Complete"
+		}
+
+		container := deployment.Spec.Template.Spec.Containers[0]
+		cpuLim, _ := container.Resources.Limits["cpu"].MarshalJSON()
+		memLim, _ := container.Resources.Limits["memory"].MarshalJSON()
+		storageLim, _ := container.Resources.Limits["ephemeral-storage"].MarshalJSON()
+
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"ATTRIBUTE", "VALUE"})
+		t.AppendRow(table.Row{"Name", deployment.Name})
+		t.AppendRow(table.Row{"Tenant-Target", deployment.Namespace})
+		t.AppendRow(table.Row{"Replicas", strconv.Itoa(int(replicas))})
+		t.AppendRow(table.Row{"Updated", strconv.Itoa(int(deployment.Status.UpdatedReplicas))})
+		t.AppendRow(table.Row{"Available", strconv.Itoa(int(deployment.Status.AvailableReplicas))})
+		t.AppendRow(table.Row{"Rollout", rollout})
+		t.AppendSeparator()
+		t.AppendRow(table.Row{"CPU-Limit", string(cpuLim)})
+		t.AppendRow(table.Row{"Memory-Limit", string(memLim)})
+		t.AppendRow(table.Row{"Storage-Limit", string(storageLim)})
+		t.AppendSeparator()
+		t.AppendRow(table.Row{"Deployed Image", container.Image})
+		for _, service := range services {
+			t.AppendRow(table.Row{"Service " + service.Name, tools.FormatServiceAddresses(service)})
+		}
+		t.AppendSeparator()
+
+		//build pod table
+		p := table.NewWriter()
+		p.SetOutputMirror(os.Stdout)
+		p.AppendHeader(table.Row{"POD", "STATUS", "DEPLOYED ON"})
+		for _, pod := range pods {
+			if pod.Namespace == deployment.Namespace && pod.Labels[tools.KUFAST_WORKLOAD_LABEL] == deployment.Name {
+				p.AppendRow(table.Row{pod.Name, pod.Status.Phase, pod.Spec.NodeName})
+			}
+		}
+		p.AppendSeparator()
+
+		s.Stop()
+		t.Render()
+		fmt.Println("\n" + "Pods:")
+		p.Render()
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	getCmd.AddCommand(getDeploymentCmd)
+
+	getDeploymentCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	getDeploymentCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
@@ -0,0 +1,122 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package get
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+	"strconv"
+)
+
+// getJobCmd represents the get job command
+var getJobCmd = &cobra.Command{
+	Use:   "job <job>",
+	Short: "Gain information about a job.",
+	Long: `Gain information about a job. Output includes name, tenant-target, status, start and completion time, the
+number of succeeded and failed pods as well as the pods belonging to the job.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_GET_OBJECTS)
+
+		job, err := clusterOperations.GetJob(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		pods, err := clusterOperations.ListTenantPods(cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		started, completed := "", ""
+		if job.Status.StartTime != nil {
+			started = job.Status.StartTime.String()
+		}
+		if job.Status.CompletionTime != nil {
+			completed = job.Status.CompletionTime.String()
+		}
+		parallelism, backoffLimit := int32(1), int32(6)
+		if job.Spec.Parallelism != nil {
+			parallelism = *job.Spec.Parallelism
+		}
+		if job.Spec.BackoffLimit != nil {
+			backoffLimit = *job.Spec.BackoffLimit
+		}
+
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"ATTRIBUTE", "VALUE"})
+		t.AppendRow(table.Row{"Name", job.Name})
+		t.AppendRow(table.Row{"Tenant-Target", job.Namespace})
+		t.AppendRow(table.Row{"Status", tools.FormatJobStatus(*job)})
+		t.AppendRow(table.Row{"Started", started})
+		t.AppendRow(table.Row{"Completed", completed})
+		t.AppendSeparator()
+		t.AppendRow(table.Row{"Parallelism", strconv.Itoa(int(parallelism))})
+		t.AppendRow(table.Row{"Backoff Limit", strconv.Itoa(int(backoffLimit))})
+		t.AppendRow(table.Row{"Failed Pods", strconv.Itoa(int(job.Status.Failed))})
+		if job.Spec.TTLSecondsAfterFinished != nil {
+			t.AppendRow(table.Row{"TTL after finished", strconv.Itoa(int(*job.Spec.TTLSecondsAfterFinished)) + "s"})
+		}
+		t.AppendSeparator()
+		t.AppendRow(table.Row{"Deployed Image", job.Spec.Template.Spec.Containers[0].Image})
+		t.AppendSeparator()
+
+		//build pod table
+		p := table.NewWriter()
+		p.SetOutputMirror(os.Stdout)
+		p.AppendHeader(table.Row{"POD", "STATUS", "DEPLOYED ON"})
+		for _, pod := range pods {
+			if pod.Namespace == job.Namespace && pod.Labels["job-name"] == job.Name {
+				p.AppendRow(table.Row{pod.Name, pod.Status.Phase, pod.Spec.NodeName})
+			}
+		}
+		p.AppendSeparator()
+
+		s.Stop()
+		t.Render()
+		fmt.Println("\n" + "Pods:")
+		p.Render()
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	getCmd.AddCommand(getJobCmd)
+
+	getJobCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	getJobCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
@@ -37,7 +37,8 @@ import (
 var getLogsCmd = &cobra.Command{
 	Use:   "logs <podname>",
 	Short: "Get the logs of a pod",
-	Long:  `Get the logs of a pod.`,
+	Long: `Get the logs of a pod. For pods with multiple containers, select the container with --container,
+otherwise the logs of the main container are shown.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		//Initial config block
 		namespaceName, err := clusterOperations.GetTenantTargetNameFromCmd(cmd)
@@ -50,7 +51,9 @@ var getLogsCmd = &cobra.Command{
 			tools.HandleError(err, cmd)
 		}
 		count := int64(100)
+		containerName, _ := cmd.Flags().GetString("container")
 		options := v1.PodLogOptions{
+			Container: containerName,
 			Follow:    true,
 			TailLines: &count,
 		}
@@ -91,6 +94,7 @@ var getLogsCmd = &cobra.Command{
 func init() {
 	getCmd.AddCommand(getLogsCmd)
 
+	getLogsCmd.Flags().StringP("container", "", "", "The container to get the logs from. Defaults to the main container of the pod.")
 	getLogsCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
 	getLogsCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
 
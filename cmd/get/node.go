@@ -0,0 +1,88 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package get
+
+import (
+	"errors"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+)
+
+// getNodeCmd represents the get node command
+var getNodeCmd = &cobra.Command{
+	Use:   "node <node>",
+	Short: "Gain information about a node.",
+	Long: `Gain information about a node. Output includes name, hostname, target-groups, status, requested and
+allocatable resources, taints, the tenants with access to the node and the number of tenant pods running on it.
+This operation can only be executed by a cluster admin.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_GET_OBJECTS)
+
+		node, err := clusterOperations.GetNodeInfo(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		var taints []string
+		for _, taint := range node.Taints {
+			taints = append(taints, taint.ToString())
+		}
+
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"ATTRIBUTE", "VALUE"})
+		t.AppendRow(table.Row{"Name", node.Name})
+		t.AppendRow(table.Row{"Hostname", node.Hostname})
+		t.AppendRow(table.Row{"Target-Groups", node.Groups})
+		t.AppendRow(table.Row{"Status", tools.FormatNodeStatus(node)})
+		t.AppendSeparator()
+		t.AppendRow(table.Row{"CPU", "Requested / Allocatable: " + tools.FormatResourceUsage(node.Requested, node.Allocatable, "cpu")})
+		t.AppendRow(table.Row{"Memory", "Requested / Allocatable: " + tools.FormatResourceUsage(node.Requested, node.Allocatable, "memory")})
+		t.AppendRow(table.Row{"Storage", "Requested / Allocatable: " + tools.FormatResourceUsage(node.Requested, node.Allocatable, "ephemeral-storage")})
+		t.AppendRow(table.Row{"Pods", "Allocatable: " + node.Allocatable.Pods().String()})
+		t.AppendSeparator()
+		t.AppendRow(table.Row{"Taints", taints})
+		t.AppendRow(table.Row{"Tenants with Node Access", node.Tenants})
+		t.AppendRow(table.Row{"# Tenant Pods", node.TenantPods})
+
+		s.Stop()
+		t.AppendSeparator()
+		t.Render()
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	getCmd.AddCommand(getNodeCmd)
+
+}
@@ -31,6 +31,7 @@ import (
 	"kufast/clusterOperations"
 	"kufast/tools"
 	"os"
+	"strings"
 )
 
 // getPodCmd represents the get pod command
@@ -38,7 +39,8 @@ var getPodCmd = &cobra.Command{
 	Use:   "pod <pod>",
 	Short: "Gain information about a deployed pod.",
 	Long: `Gain information about a deployed pod. Output includes name, tenant-target, status, node, limits, image,
-restart policy and IP-address`,
+restart policy, IP-address and the addresses of all services exposing the pod. Limits and image are shown for the
+main container or the container selected with --container.`,
 	Run: func(cmd *cobra.Command, args []string) {
 
 		if len(args) != 1 {
@@ -57,12 +59,23 @@ restart policy and IP-address`,
 			tools.HandleError(err, cmd)
 		}
 
-		cpuLim, _ := pod.Spec.Containers[0].Resources.Limits["cpu"].MarshalJSON()
-		cpuReq, _ := pod.Spec.Containers[0].Resources.Requests["cpu"].MarshalJSON()
-		memLim, _ := pod.Spec.Containers[0].Resources.Limits["memory"].MarshalJSON()
-		memReq, _ := pod.Spec.Containers[0].Resources.Requests["memory"].MarshalJSON()
-		storageLim, _ := pod.Spec.Containers[0].Resources.Limits["ephemeral-storage"].MarshalJSON()
-		storageReq, _ := pod.Spec.Containers[0].Resources.Requests["ephemeral-storage"].MarshalJSON()
+		services, err := clusterOperations.ListPodServices(pod.Namespace, pod.Labels, cmd)
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+
+		containerName, _ := cmd.Flags().GetString("container")
+		container, err := clusterOperations.GetPodContainer(pod, containerName)
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+
+		cpuLim, _ := container.Resources.Limits["cpu"].MarshalJSON()
+		cpuReq, _ := container.Resources.Requests["cpu"].MarshalJSON()
+		memLim, _ := container.Resources.Limits["memory"].MarshalJSON()
+		memReq, _ := container.Resources.Requests["memory"].MarshalJSON()
+		storageLim, _ := container.Resources.Limits["ephemeral-storage"].MarshalJSON()
+		storageReq, _ := container.Resources.Requests["ephemeral-storage"].MarshalJSON()
 
 		t := table.NewWriter()
 		t.SetOutputMirror(os.Stdout)
@@ -70,7 +83,15 @@ restart policy and IP-address`,
 		t.AppendRow(table.Row{"Name", pod.Name})
 		t.AppendRow(table.Row{"Tenant-Target", pod.Namespace})
 		t.AppendRow(table.Row{"Status", pod.Status.Phase})
+		t.AppendRow(table.Row{"Ready", tools.FormatPodReadiness(*pod)})
+		t.AppendRow(table.Row{"QoS Class", pod.Status.QOSClass})
 		t.AppendRow(table.Row{"Deployed on", pod.Spec.NodeName})
+		t.AppendRow(table.Row{"Containers", tools.FormatContainerStates(*pod)})
+		t.AppendSeparator()
+		t.AppendRow(table.Row{"Container", container.Name})
+		t.AppendRow(table.Row{"Liveness Probe", tools.FormatProbe(container.LivenessProbe)})
+		t.AppendRow(table.Row{"Readiness Probe", tools.FormatProbe(container.ReadinessProbe)})
+		t.AppendRow(table.Row{"Startup Probe", tools.FormatProbe(container.StartupProbe)})
 		t.AppendSeparator()
 		t.AppendRow(table.Row{"CPU-Limit", "Limit: " + string(cpuLim) +
 			"\nRequests: " + string(cpuReq)})
@@ -82,9 +103,17 @@ restart policy and IP-address`,
 			"\nRequests: " + string(storageReq)})
 		t.AppendSeparator()
 		t.AppendRow(table.Row{"Attached Storage"})
-		t.AppendRow(table.Row{"Deployed Image", pod.Spec.Containers[0].Image})
+		t.AppendRow(table.Row{"Deployed Image", container.Image})
+		t.AppendRow(table.Row{"Pull Policy", container.ImagePullPolicy})
+		t.AppendRow(table.Row{"Command", strings.Join(container.Command, " ")})
+		t.AppendRow(table.Row{"Arguments", strings.Join(container.Args, " ")})
+		t.AppendRow(table.Row{"Working Directory", container.WorkingDir})
+		t.AppendRow(table.Row{"Run as User", tools.FormatRunAsUser(container)})
 		t.AppendRow(table.Row{"Restart Policy", pod.Spec.RestartPolicy})
 		t.AppendRow(table.Row{"IP Address", pod.Status.PodIP})
+		for _, service := range services {
+			t.AppendRow(table.Row{"Service " + service.Name, tools.FormatServiceAddresses(service)})
+		}
 
 		t.AppendSeparator()
 		s.Stop()
@@ -104,6 +133,7 @@ restart policy and IP-address`,
 func init() {
 	getCmd.AddCommand(getPodCmd)
 
+	getPodCmd.Flags().StringP("container", "", "", "The container to show. Defaults to the main container of the pod.")
 	getPodCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
 	getPodCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
 }
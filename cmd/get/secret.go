@@ -30,13 +30,14 @@ import (
 	"kufast/clusterOperations"
 	"kufast/tools"
 	"os"
+	"sort"
 )
 
 // getSecretCmd represents the get secret command
 var getSecretCmd = &cobra.Command{
 	Use:   "secret <secret>",
 	Short: "Gain information about a secret.",
-	Long:  `Gain information about a secret. Output includes name, tenant-target and the secret data.`,
+	Long:  `Gain information about a secret. Output includes name, tenant-target and the secret data of all keys.`,
 	Run: func(cmd *cobra.Command, args []string) {
 
 		if len(args) != 1 {
@@ -50,17 +51,30 @@ var getSecretCmd = &cobra.Command{
 			tools.HandleError(err, cmd)
 		}
 
-		if secret.Data["secret"] == nil {
+		if secret.Type != "Opaque" {
 			err := errors.New("Error: This is not a valid secret")
 			tools.HandleError(err, cmd)
 		}
 
+		var keys []string
+		for key := range secret.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
 		t := table.NewWriter()
 		t.SetOutputMirror(os.Stdout)
 		t.AppendHeader(table.Row{"ATTRIBUTE", "VALUE"})
 		t.AppendRow(table.Row{"Name", secret.Name})
 		t.AppendRow(table.Row{"Namespace", secret.Namespace})
-		t.AppendRow(table.Row{"Data", string(secret.Data["secret"])})
+		if len(keys) == 1 && keys[0] == "secret" {
+			t.AppendRow(table.Row{"Data", string(secret.Data["secret"])})
+		} else {
+			t.AppendSeparator()
+			for _, key := range keys {
+				t.AppendRow(table.Row{key, string(secret.Data[key])})
+			}
+		}
 
 		s.Stop()
 
@@ -0,0 +1,126 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package get
+
+import (
+	"errors"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+)
+
+// getTargetGroupCmd represents the get target-group command
+var getTargetGroupCmd = &cobra.Command{
+	Use:   "target-group <target-group>",
+	Short: "Gain information about a target-group.",
+	Long: `Gain information about a target-group. Output includes the member nodes, the tenants with access,
+the tenant-targets deploying to it and the quota of the group as allocated versus available resources.
+This operation can only be executed by a cluster admin.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_GET_OBJECTS)
+
+		if !clusterOperations.IsValidTarget(cmd, args[0], true) {
+			s.Stop()
+			tools.HandleError(errors.New("Target-group "+args[0]+" does not exist."), cmd)
+		}
+
+		nodes, err := clusterOperations.ListTargetGroupNodes(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		tenants, err := clusterOperations.ListTenantsWithGroupAccess(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		tenantTargets, err := clusterOperations.ListTenantTargetsOnTargetGroup(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+		var tenantTargetNames []string
+		for _, tenantTarget := range tenantTargets {
+			tenantTargetNames = append(tenantTargetNames, tenantTarget.Name)
+		}
+
+		quota, err := clusterOperations.GetTargetGroupQuota(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		allocated, err := clusterOperations.GetTargetGroupAllocation(args[0], "", cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"ATTRIBUTE", "VALUE"})
+		t.AppendRow(table.Row{"Name", args[0]})
+		t.AppendRow(table.Row{"Nodes", nodes})
+		t.AppendRow(table.Row{"Tenants with Group Access", tenants})
+		t.AppendRow(table.Row{"Tenant-Targets", tenantTargetNames})
+		t.AppendSeparator()
+		for _, name := range []v1.ResourceName{"limits.cpu", "limits.memory", "limits.ephemeral-storage", "pods"} {
+			if limit, ok := quota[name]; ok {
+				t.AppendRow(table.Row{"Quota " + string(name), "Allocated / Quota: " + tools.FormatResourceUsage(allocated, quota, name) +
+					"\nAvailable: " + availableQuantity(limit, allocated[name])})
+			} else {
+				allocatedQty := allocated[name]
+				t.AppendRow(table.Row{"Quota " + string(name), "Allocated: " + allocatedQty.String() + "\nNo quota set"})
+			}
+		}
+
+		s.Stop()
+		t.AppendSeparator()
+		t.Render()
+	},
+}
+
+// availableQuantity returns the difference between a limit and the allocated amount as a string
+func availableQuantity(limit resource.Quantity, allocated resource.Quantity) string {
+	available := limit.DeepCopy()
+	available.Sub(allocated)
+	return available.String()
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	getCmd.AddCommand(getTargetGroupCmd)
+
+}
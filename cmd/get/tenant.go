@@ -36,7 +36,7 @@ import (
 var getTenantCmd = &cobra.Command{
 	Use:   "tenant <tenant name>",
 	Short: "Gain information about a deployed tenant.",
-	Long:  `Gain information about a deployed tenant. Output includes name, node access and group access`,
+	Long:  `Gain information about a deployed tenant. Output includes name, node access, group access and the domains allowed for ingresses`,
 	Run: func(cmd *cobra.Command, args []string) {
 
 		if len(args) != 1 {
@@ -54,6 +54,11 @@ var getTenantCmd = &cobra.Command{
 		if err != nil {
 			tools.HandleError(err, cmd)
 		}
+		domains, err := clusterOperations.GetTenantDomains(cmd, args[0])
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+
 		var groupTargets []string
 		var nodeTargets []string
 
@@ -71,6 +76,7 @@ var getTenantCmd = &cobra.Command{
 		t.AppendRow(table.Row{"Name", tenant.Name})
 		t.AppendRow(table.Row{"Node Access", nodeTargets})
 		t.AppendRow(table.Row{"Group Access", groupTargets})
+		t.AppendRow(table.Row{"Allowed Domains", domains})
 
 		s.Stop()
 
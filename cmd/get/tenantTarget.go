@@ -38,7 +38,8 @@ import (
 var getTenantTargetCmd = &cobra.Command{
 	Use:   "tenant-target <tenant-target>",
 	Short: "Gain information on a tenant target.",
-	Long:  `Gain information on a tenant target. Lists name, status, limits, their usage, and the number of included pods`,
+	Long: `Gain information on a tenant target. Lists name, status, placement policy, security level, limits, their usage, the number of included pods
+and the usage of persistent volumes`,
 	Run: func(cmd *cobra.Command, args []string) {
 
 		if len(args) != 1 {
@@ -75,6 +76,24 @@ var getTenantTargetCmd = &cobra.Command{
 			tools.HandleError(err, cmd)
 		}
 
+		placement, err := clusterOperations.GetTenantTargetPlacement(cmd, tenantName, args[0])
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+
+		security, err := clusterOperations.GetTenantTargetSecurityLevel(cmd, tenantName, args[0])
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+
+		storageClasses, err := clusterOperations.GetTenantTargetStorageClasses(cmd, tenantName, args[0])
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+		if len(storageClasses) == 0 {
+			storageClasses = []string{"all"}
+		}
+
 		cpuLim, _ := quota.Spec.Hard["limits.cpu"].MarshalJSON()
 		cpuReq, _ := quota.Spec.Hard["requests.cpu"].MarshalJSON()
 		memLim, _ := quota.Spec.Hard["limits.memory"].MarshalJSON()
@@ -87,6 +106,8 @@ var getTenantTargetCmd = &cobra.Command{
 		t.AppendHeader(table.Row{"ATTRIBUTE", "VALUE"})
 		t.AppendRow(table.Row{"name", nameSpace.Name})
 		t.AppendRow(table.Row{"Status", nameSpace.Status.Phase})
+		t.AppendRow(table.Row{"Placement", placement})
+		t.AppendRow(table.Row{"Security", security})
 		t.AppendSeparator()
 		t.AppendRow(table.Row{"CPU-Limit", "Limit: " + string(cpuLim) +
 			"\nRequests: " + string(cpuReq)})
@@ -101,6 +122,10 @@ var getTenantTargetCmd = &cobra.Command{
 		t.AppendSeparator()
 		t.AppendRow(table.Row{"# Pods", len(pods.Items)})
 		t.AppendSeparator()
+		t.AppendRow(table.Row{"Volumes", tools.FormatResourceUsage(quota.Status.Used, quota.Spec.Hard, "persistentvolumeclaims")})
+		t.AppendRow(table.Row{"Volume Storage", tools.FormatResourceUsage(quota.Status.Used, quota.Spec.Hard, "requests.storage")})
+		t.AppendRow(table.Row{"Storage Classes", storageClasses})
+		t.AppendSeparator()
 
 		s.Stop()
 		t.Render()
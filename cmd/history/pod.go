@@ -0,0 +1,79 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package history
+
+import (
+	"errors"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// historyPodCmd represents the history pod command
+var historyPodCmd = &cobra.Command{
+	Use:   "pod <name>",
+	Short: "Show the revision history of a pod",
+	Long: `Shows the revisions of a pod, kufast has applied with create pod, update pod or rollback pod.
+The latest ` + strconv.Itoa(tools.KUFAST_HISTORY_LIMIT) + ` revisions are kept in the annotation ` + tools.KUFAST_HISTORY_ANNOTATION + ` of the pod,
+so they do not count towards the quota of the tenant-target. The history is removed together with the pod.
+Use 'kufast rollback pod' to recreate the pod from one of the revisions.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+		s := tools.CreateStandardSpinner(tools.MESSAGE_GET_OBJECTS)
+
+		revisions, err := clusterOperations.GetPodHistory(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"REVISION", "CREATED", "IMAGE", "RESOURCES (LIMIT/REQUEST)", "SECRETS", "PORTS", "COMMAND"})
+		for _, revision := range revisions {
+			container := revision.Spec.Containers[0]
+			t.AppendRow(table.Row{revision.Revision, revision.Created.Format("2006-01-02 15:04:05"), container.Image,
+				tools.FormatContainerResources(container), tools.FormatContainerSecrets(container),
+				tools.FormatContainerPorts(container), strings.Join(container.Command, " ")})
+		}
+		t.AppendSeparator()
+		s.Stop()
+		t.Render()
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	historyCmd.AddCommand(historyPodCmd)
+
+	historyPodCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	historyPodCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
@@ -0,0 +1,60 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package history
+
+import (
+	"github.com/spf13/cobra/doc"
+	"kufast/cmd"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// historyCmd represents the history root command. It cannot be executed itself but only its subcommands.
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the revision history of kufast objects.",
+	Long: `The history subcommand is a collection of all history operations available in kufast.
+Every specification kufast applies to a pod is recorded as a revision in its tenant-target.`,
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	cmd.RootCmd.AddCommand(historyCmd)
+
+}
+
+func CreateHistoryDocs(fileP func(string) string, linkH func(string) string) {
+
+	err := os.MkdirAll("./kufast.wiki/history/", 0770)
+	if err != nil {
+		panic(err)
+	}
+
+	err = doc.GenMarkdownTreeCustom(historyCmd, "./kufast.wiki/history/", fileP, linkH)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
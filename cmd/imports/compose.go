@@ -0,0 +1,103 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package imports
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+	"strings"
+)
+
+// importComposeCmd represents the import compose command
+var importComposeCmd = &cobra.Command{
+	Use:   "compose",
+	Short: "Import the services of a docker-compose file",
+	Long: `Imports the services of a docker-compose file into a tenant-target. Services restarted always or unless-stopped
+and services with replicas become deployments, all others become pods. Images, commands, entrypoints, environment
+variables, env files, restart policies and resource limits are taken over. Ports become a service named after the compose
+service, so services can reach each other by their names as in docker-compose. The pods and deployments are named after
+the container_name of a service, if present. Published ports differing from the container port are not supported.
+Secrets read from a file or an environment variable become kufast secrets. Resources not set in the compose file are taken from the flags.
+All parts of the compose file kufast cannot import are reported. Use --dry-run to check them before creating anything.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 0 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_CREATE_OBJECTS)
+
+		created, warnings, err := clusterOperations.ImportCompose(cmd)
+		s.Stop()
+
+		header := "CREATED"
+		if dryRun {
+			header = "TO BE CREATED"
+		}
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{header, "NAME"})
+		for _, object := range created {
+			kind, name, _ := strings.Cut(object, " ")
+			t.AppendRow(table.Row{kind, name})
+		}
+		t.AppendSeparator()
+		if len(created) > 0 {
+			t.Render()
+		}
+
+		if len(warnings) > 0 {
+			fmt.Println("\n" + "Not imported:")
+			for _, warning := range warnings {
+				fmt.Println("- " + warning)
+			}
+		}
+
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+		fmt.Println(tools.MESSAGE_DONE)
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	importCmd.AddCommand(importComposeCmd)
+
+	importComposeCmd.Flags().StringP("file", "f", "docker-compose.yml", "The docker-compose file to import.")
+	importComposeCmd.Flags().BoolP("dry-run", "", false, "Only show what would be created and what cannot be imported.")
+	importComposeCmd.Flags().StringP("type", "", "clusterip", "The type of the services for ports. Either clusterip or nodeport.")
+	importComposeCmd.Flags().StringP("memory", "", "500Mi", "The amount of RAM of services without a memory limit")
+	importComposeCmd.Flags().StringP("cpu", "", "500m", "The amount of CPU of services without a CPU limit")
+	importComposeCmd.Flags().StringP("storage", "", "1Gi", "The amount of storage of each service")
+	importComposeCmd.Flags().StringP("deploy-secret", "d", "", "A deploy-secret to pull private images of all services.")
+
+	importComposeCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	importComposeCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
@@ -0,0 +1,83 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package list
+
+import (
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+)
+
+// listCronJobsCmd represents the list cronjobs command
+var listCronJobsCmd = &cobra.Command{
+	Use:   "cronjobs",
+	Short: "List all cronjobs of a tenant",
+	Long: `List all cronjobs in your tenant-targets. The overview contains the schedule of each cronjob and the status
+of its last run. To gain further information see the kufast get cronjob command.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_GET_OBJECTS)
+
+		cronJobs, err := clusterOperations.ListTenantCronJobs(cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		jobs, err := clusterOperations.ListTenantJobs(cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		//build table
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"NAME", "NAMESPACE", "SCHEDULE", "LAST RUN", "LAST STATUS"})
+		for _, cronJob := range cronJobs {
+			lastRun, lastStatus := "", ""
+			if cronJob.Status.LastScheduleTime != nil {
+				lastRun = cronJob.Status.LastScheduleTime.String()
+			}
+			runs := clusterOperations.GetCronJobRuns(cronJob, jobs)
+			if len(runs) > 0 {
+				lastStatus = tools.FormatJobStatus(runs[len(runs)-1])
+			}
+			t.AppendRow(table.Row{cronJob.Name, cronJob.Namespace, cronJob.Spec.Schedule, lastRun, lastStatus})
+
+		}
+		s.Stop()
+		t.AppendSeparator()
+		t.Render()
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	listCmd.AddCommand(listCronJobsCmd)
+	listCronJobsCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
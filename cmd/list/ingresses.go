@@ -0,0 +1,85 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package list
+
+import (
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+	"strconv"
+)
+
+// listIngressesCmd represents the list ingresses command
+var listIngressesCmd = &cobra.Command{
+	Use:   "ingresses",
+	Short: "List all ingresses of a tenant",
+	Long: `List all ingresses in your tenant-targets. The overview contains the hostname and path of each ingress and
+the service requests are routed to.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_GET_OBJECTS)
+
+		ingresses, err := clusterOperations.ListTenantIngresses(cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		//build table
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"NAME", "NAMESPACE", "URL", "SERVICE"})
+		for _, ingress := range ingresses {
+			scheme := "http://"
+			if len(ingress.Spec.TLS) > 0 {
+				scheme = "https://"
+			}
+			for _, rule := range ingress.Spec.Rules {
+				if rule.HTTP == nil {
+					continue
+				}
+				for _, path := range rule.HTTP.Paths {
+					service := ""
+					if path.Backend.Service != nil {
+						service = path.Backend.Service.Name + ":" + strconv.Itoa(int(path.Backend.Service.Port.Number))
+					}
+					t.AppendRow(table.Row{ingress.Name, ingress.Namespace, scheme + rule.Host + path.Path, service})
+				}
+			}
+
+		}
+		s.Stop()
+		t.AppendSeparator()
+		t.Render()
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	listCmd.AddCommand(listIngressesCmd)
+	listIngressesCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
@@ -0,0 +1,72 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package list
+
+import (
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+)
+
+// listNodesCmd represents the list nodes command
+var listNodesCmd = &cobra.Command{
+	Use:   "nodes",
+	Short: "List all nodes in this cluster.",
+	Long: `List all nodes in this cluster. The overview contains the hostname, the target-groups, the readiness,
+the requested and allocatable resources, the tenants with access and the number of tenant pods of each node.
+This operation can only be executed by a cluster admin.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_GET_OBJECTS)
+
+		nodes, err := clusterOperations.ListNodeInfos(cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		//build table
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"NAME", "HOSTNAME", "GROUPS", "STATUS", "CPU", "MEMORY", "# TAINTS", "TENANTS", "# TENANT PODS"})
+		for _, node := range nodes {
+			t.AppendRow(table.Row{node.Name, node.Hostname, node.Groups, tools.FormatNodeStatus(node),
+				tools.FormatResourceUsage(node.Requested, node.Allocatable, "cpu"),
+				tools.FormatResourceUsage(node.Requested, node.Allocatable, "memory"),
+				len(node.Taints), node.Tenants, node.TenantPods})
+		}
+
+		s.Stop()
+		t.AppendSeparator()
+		t.Render()
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	listCmd.AddCommand(listNodesCmd)
+
+}
@@ -50,9 +50,9 @@ To gain further information see the kubectl get pod command.`,
 		//build table
 		t := table.NewWriter()
 		t.SetOutputMirror(os.Stdout)
-		t.AppendHeader(table.Row{"NAME", "NAMESPACE", "STATUS", "POD MESSAGE"})
+		t.AppendHeader(table.Row{"NAME", "NAMESPACE", "STATUS", "READY", "POD MESSAGE"})
 		for _, pod := range pods {
-			t.AppendRow(table.Row{pod.Name, pod.Namespace, pod.Status.Phase, pod.Status.Message})
+			t.AppendRow(table.Row{pod.Name, pod.Namespace, pod.Status.Phase, tools.FormatPodReadiness(pod), pod.Status.Message})
 
 		}
 		s.Stop()
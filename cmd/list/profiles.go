@@ -0,0 +1,94 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package list
+
+import (
+	"fmt"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// listProfilesCmd represents the list profiles command
+var listProfilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "List all pod profiles",
+	Long: `List all pod profiles stored on your machine and shared in the tenant-target. The overview contains the
+settings of each profile. Local profiles take precedence over shared profiles with the same name.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_GET_OBJECTS)
+
+		localProfiles, err := tools.ReadLocalProfiles()
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+		sharedProfiles, invalidProfiles, err := clusterOperations.ListSharedProfiles(cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		//build table
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"NAME", "STORED", "IMAGE", "CPU", "MEMORY", "STORAGE", "SECRETS", "PORTS", "COMMAND", "RESTART"})
+		for source, profiles := range map[string]map[string]tools.PodProfile{"local": localProfiles, "tenant-target": sharedProfiles} {
+			for name, profile := range profiles {
+				var ports []string
+				for _, port := range profile.Ports {
+					ports = append(ports, strconv.Itoa(int(port)))
+				}
+				t.AppendRow(table.Row{name, source, profile.Image, profile.Cpu, profile.Memory, profile.Storage,
+					strings.Join(profile.Secrets, ","), strings.Join(ports, ","), strings.Join(profile.Cmd, " "), profile.Restart})
+			}
+		}
+		t.SortBy([]table.SortBy{{Name: "NAME", Mode: table.Asc}, {Name: "STORED", Mode: table.Asc}})
+
+		s.Stop()
+		t.AppendSeparator()
+		t.Render()
+
+		if len(invalidProfiles) > 0 {
+			fmt.Println("\n" + "Skipped invalid shared profiles:")
+			for _, invalidProfile := range invalidProfiles {
+				fmt.Println("- " + invalidProfile)
+			}
+		}
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	listCmd.AddCommand(listProfilesCmd)
+
+	listProfilesCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	listProfilesCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+
+}
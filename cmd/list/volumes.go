@@ -0,0 +1,77 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package list
+
+import (
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	v1 "k8s.io/api/core/v1"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+)
+
+// listVolumesCmd represents the list volumes command
+var listVolumesCmd = &cobra.Command{
+	Use:   "volumes",
+	Short: "List all persistent volumes of a tenant",
+	Long: `List all persistent volumes in your tenant-targets. The overview contains the size, storage class, access
+mode and status of each volume.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_GET_OBJECTS)
+
+		volumes, err := clusterOperations.ListTenantVolumes(cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		//build table
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"NAME", "NAMESPACE", "SIZE", "STORAGE CLASS", "ACCESS MODE", "STATUS"})
+		for _, volume := range volumes {
+			size := volume.Spec.Resources.Requests[v1.ResourceStorage]
+			storageClass := ""
+			if volume.Spec.StorageClassName != nil {
+				storageClass = *volume.Spec.StorageClassName
+			}
+			t.AppendRow(table.Row{volume.Name, volume.Namespace, size.String(), storageClass, volume.Spec.AccessModes,
+				volume.Status.Phase})
+		}
+
+		s.Stop()
+		t.AppendSeparator()
+		t.Render()
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	listCmd.AddCommand(listVolumesCmd)
+
+	listVolumesCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+
+}
@@ -0,0 +1,90 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package maintenance
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+)
+
+// maintenanceEndCmd represents the maintenance end command
+var maintenanceEndCmd = &cobra.Command{
+	Use:   "end <node>",
+	Short: "End the maintenance of a node.",
+	Long: `End the maintenance of a node. The node is uncordoned, so new pods can be scheduled to it again,
+and all affected tenant-targets receive an event that the maintenance is over. Pods that have been rescheduled
+during the maintenance are not moved back. This operation can only be executed by a cluster admin.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+
+		message, _ := cmd.Flags().GetString("message")
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_UPDATE_OBJECTS)
+
+		node, err := clusterOperations.GetNode(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		err = clusterOperations.SetNodeSchedulable(node.Name, true, cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		tenantTargets, err := clusterOperations.ListTenantTargetsOnNode(node, cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		if message == "" {
+			message = "Maintenance of node " + node.Name + " has ended. New pods can be deployed to it again."
+		}
+		err = clusterOperations.NotifyTenantTargets(tenantTargets, "NodeMaintenanceEnded", message, cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		s.Stop()
+		fmt.Println(tools.MESSAGE_DONE)
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	maintenanceCmd.AddCommand(maintenanceEndCmd)
+
+	maintenanceEndCmd.Flags().StringP("message", "m", "", "A custom message for the event sent to the affected tenant-targets.")
+
+}
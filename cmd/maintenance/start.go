@@ -0,0 +1,160 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package maintenance
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+)
+
+// maintenanceStartCmd represents the maintenance start command
+var maintenanceStartCmd = &cobra.Command{
+	Use:   "start <node>",
+	Short: "Start the maintenance of a node.",
+	Long: `Start the maintenance of a node. The node is cordoned, so no new pods will be scheduled to it.
+Afterwards all tenant-targets and pods affected by the maintenance are listed and each affected tenant-target
+receives an event about the maintenance. With --reschedule, keep-alive pods of tenant-targets on a target-group
+are recreated on another node of the same target-group and pods of deployments and jobs are evicted, so they are
+replaced on another node. This operation can only be executed by a cluster admin.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+
+		reschedule, _ := cmd.Flags().GetBool("reschedule")
+		message, _ := cmd.Flags().GetString("message")
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_UPDATE_OBJECTS)
+
+		node, err := clusterOperations.GetNode(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		err = clusterOperations.SetNodeSchedulable(node.Name, false, cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		tenantTargets, err := clusterOperations.ListTenantTargetsOnNode(node, cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		pods, err := clusterOperations.ListTenantPodsOnNode(node.Name, tenantTargets, cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		if message == "" {
+			message = "Node " + node.Name + " is going into maintenance. Pods running on it may be stopped."
+		}
+		err = clusterOperations.NotifyTenantTargets(tenantTargets, "NodeMaintenanceStarted", message, cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		//build tables
+		targetTable := table.NewWriter()
+		targetTable.SetOutputMirror(os.Stdout)
+		targetTable.AppendHeader(table.Row{"AFFECTED TENANT-TARGET", "TENANT", "TARGET", "TYPE"})
+		for _, tenantTarget := range tenantTargets {
+			target, _ := clusterOperations.GetTargetFromTenantTarget(&tenantTarget)
+			targetTable.AppendRow(table.Row{tenantTarget.Name, tenantTarget.ObjectMeta.Labels[tools.KUFAST_TENANT_LABEL], target.Name, target.AccessType})
+		}
+
+		podTable := table.NewWriter()
+		podTable.SetOutputMirror(os.Stdout)
+		podTable.AppendHeader(table.Row{"AFFECTED POD", "TENANT-TARGET", "STATUS", "KEEP-ALIVE"})
+		for _, pod := range pods {
+			podTable.AppendRow(table.Row{pod.Name, pod.Namespace, pod.Status.Phase, clusterOperations.IsKeepAlivePod(pod)})
+		}
+
+		var rescheduleOps []<-chan string
+		var rescheduleResults []string
+
+		if reschedule {
+			for _, pod := range pods {
+				isOwned := len(pod.OwnerReferences) > 0
+				if !isOwned && !clusterOperations.IsKeepAlivePod(pod) {
+					continue
+				}
+				for _, tenantTarget := range tenantTargets {
+					target, _ := clusterOperations.GetTargetFromTenantTarget(&tenantTarget)
+					if tenantTarget.Name != pod.Namespace {
+						continue
+					}
+					if target.AccessType == "group" && isOwned {
+						rescheduleOps = append(rescheduleOps, clusterOperations.EvictPod(pod, cmd))
+					} else if target.AccessType == "group" {
+						rescheduleOps = append(rescheduleOps, clusterOperations.ReschedulePod(pod, cmd))
+					} else {
+						rescheduleResults = append(rescheduleResults, "Pod "+pod.Name+" in "+pod.Namespace+
+							" is bound to the node and cannot be rescheduled.")
+					}
+				}
+			}
+
+			//Ensure all operations are done
+			for _, op := range rescheduleOps {
+				rescheduleResults = append(rescheduleResults, <-op)
+			}
+		}
+
+		s.Stop()
+		targetTable.AppendSeparator()
+		targetTable.Render()
+		podTable.AppendSeparator()
+		podTable.Render()
+
+		for _, res := range rescheduleResults {
+			if res != "" {
+				fmt.Println(res)
+			}
+		}
+
+		fmt.Println(tools.MESSAGE_DONE)
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	maintenanceCmd.AddCommand(maintenanceStartCmd)
+
+	maintenanceStartCmd.Flags().BoolP("reschedule", "r", false, "Recreate keep-alive pods on another node of the same target-group and evict pods of deployments and jobs.")
+	maintenanceStartCmd.Flags().StringP("message", "m", "", "A custom message for the event sent to the affected tenant-targets.")
+
+}
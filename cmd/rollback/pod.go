@@ -0,0 +1,70 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package rollback
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+)
+
+// rollbackPodCmd represents the rollback pod command
+var rollbackPodCmd = &cobra.Command{
+	Use:   "pod <name>",
+	Short: "Recreate a pod from a previous revision",
+	Long: `Recreates a pod from a revision of its history. Without --to-revision, the revision before the latest one is used.
+Use 'kufast history pod' to list the available revisions. The rollback is recorded as a new revision.
+The command waits until the recreated pod is running.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+		revision, _ := cmd.Flags().GetInt("to-revision")
+		s := tools.CreateStandardSpinner(tools.MESSAGE_UPDATE_OBJECTS)
+
+		res := clusterOperations.RollbackPod(args[0], revision, cmd)
+		err := <-res
+		s.Stop()
+		if err != "" {
+			tools.HandleError(errors.New(err), cmd)
+		}
+		//Warnings are sent after the result
+		for warning := range res {
+			fmt.Println(warning)
+		}
+		fmt.Println(tools.MESSAGE_DONE)
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	rollbackCmd.AddCommand(rollbackPodCmd)
+
+	rollbackPodCmd.Flags().IntP("to-revision", "", 0, "The revision to roll back to. Defaults to the revision before the latest one.")
+	rollbackPodCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	rollbackPodCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
@@ -0,0 +1,157 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+	v1 "k8s.io/api/core/v1"
+	"kufast/clusterOperations"
+	"kufast/tools"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the pods, quota usage and latest events of all your tenant-targets.",
+	Long: `Show a dashboard of all your tenant-targets. It lists all pods with their status, restarts, node and age,
+the usage of the quota of every tenant-target and the latest events.
+With --watch, the dashboard is redrawn whenever a pod, quota or event changes, until you press Ctrl+C.
+Tenant-targets added to or removed from your tenant while watching are picked up as well.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		watch, _ := cmd.Flags().GetBool("watch")
+		if watch {
+			err := clusterOperations.WatchTenantStatus(cmd, func(status *tools.TenantStatus) {
+				//Clear the terminal and draw the dashboard in a single write to avoid flickering
+				fmt.Print("\033[H\033[2J" + renderTenantStatus(status) + "Watching for changes. Press Ctrl+C to exit.\n")
+			})
+			if err != nil {
+				tools.HandleError(err, cmd)
+			}
+			return
+		}
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_GET_OBJECTS)
+		status, err := clusterOperations.GetTenantStatus(cmd)
+		s.Stop()
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+		fmt.Print(renderTenantStatus(status))
+
+	},
+}
+
+// renderTenantStatus renders the tables of the status dashboard into a string.
+func renderTenantStatus(status *tools.TenantStatus) string {
+	var dashboard strings.Builder
+
+	sort.Slice(status.Pods, func(i, j int) bool {
+		if status.Pods[i].Namespace != status.Pods[j].Namespace {
+			return status.Pods[i].Namespace < status.Pods[j].Namespace
+		}
+		return status.Pods[i].Name < status.Pods[j].Name
+	})
+	pods := table.NewWriter()
+	pods.SetTitle("Pods")
+	pods.AppendHeader(table.Row{"NAME", "TENANT-TARGET", "STATUS", "READY", "RESTARTS", "NODE", "AGE"})
+	for _, pod := range status.Pods {
+		pods.AppendRow(table.Row{pod.Name, pod.Namespace, pod.Status.Phase, tools.FormatPodReadiness(pod),
+			tools.FormatPodRestarts(pod), pod.Spec.NodeName, tools.FormatAge(pod.CreationTimestamp.Time)})
+	}
+	dashboard.WriteString(pods.Render() + "\n")
+
+	var namespaceNames []string
+	for namespaceName := range status.Quotas {
+		namespaceNames = append(namespaceNames, namespaceName)
+	}
+	sort.Strings(namespaceNames)
+	quotas := table.NewWriter()
+	quotas.SetTitle("Quota usage")
+	quotas.AppendHeader(table.Row{"TENANT-TARGET", "RESOURCE", "USAGE"})
+	for _, namespaceName := range namespaceNames {
+		quota := status.Quotas[namespaceName]
+		var resourceNames []v1.ResourceName
+		for resourceName := range quota.Spec.Hard {
+			resourceNames = append(resourceNames, resourceName)
+		}
+		sort.Slice(resourceNames, func(i, j int) bool {
+			return resourceNames[i] < resourceNames[j]
+		})
+		for _, resourceName := range resourceNames {
+			quotas.AppendRow(table.Row{namespaceName, resourceName,
+				tools.FormatUsageBar(quota.Status.Used[resourceName], quota.Spec.Hard[resourceName])})
+		}
+		quotas.AppendSeparator()
+	}
+	dashboard.WriteString(quotas.Render() + "\n")
+
+	events := table.NewWriter()
+	events.SetTitle("Latest events")
+	events.AppendHeader(table.Row{"AGE", "TENANT-TARGET", "OBJECT", "REASON", "MESSAGE"})
+	for _, event := range status.Events {
+		events.AppendRow(table.Row{tools.FormatAge(tools.GetEventTime(event)), event.Namespace,
+			strings.ToLower(event.InvolvedObject.Kind) + "/" + event.InvolvedObject.Name, event.Reason, event.Message})
+	}
+	dashboard.WriteString(events.Render() + "\n")
+	dashboard.WriteString("Updated at " + time.Now().Format("15:04:05") + "\n")
+
+	return dashboard.String()
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	RootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().BoolP("watch", "w", false, "Keep the dashboard open and redraw it on every change.")
+	statusCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+
+}
+
+func CreateStatusDocs(linkH func(string) string) {
+	out, err := os.Create("./kufast.wiki/status.md")
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		err := out.Close()
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	err = doc.GenMarkdownCustom(statusCmd, out, linkH)
+	if err != nil {
+		panic(err)
+	}
+
+}
@@ -0,0 +1,78 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package update
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+)
+
+// updateDeploymentCmd represents the update deployment command
+var updateDeploymentCmd = &cobra.Command{
+	Use:   "deployment <name>",
+	Short: "Update the image, replicas or settings of a deployment",
+	Long: `Updates an existing deployment. Only the flags you provide are changed, everything else stays as it is.
+Changes of the pods are rolled out one after another, so your workload stays available during the update.
+The command waits until all replicas are updated and available.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+		s := tools.CreateStandardSpinner(tools.MESSAGE_UPDATE_OBJECTS)
+
+		err := clusterOperations.UpdateDeployment(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		err = clusterOperations.WaitForDeploymentRollout(args[0], cmd, func(status string) {
+			s.Lock()
+			s.Suffix = status
+			s.Unlock()
+		})
+		s.Stop()
+		if err != nil {
+			tools.HandleError(err, cmd)
+		}
+		fmt.Println(tools.MESSAGE_DONE)
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	updateCmd.AddCommand(updateDeploymentCmd)
+
+	//Settings for the deployment
+	updateDeploymentCmd.Flags().StringP("image", "i", "", "The new image of the pods.")
+	updateDeploymentCmd.Flags().Int32P("replicas", "r", 1, "The number of pods the deployment should run.")
+	tools.AddPodFlags(updateDeploymentCmd, false)
+
+	updateDeploymentCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	updateDeploymentCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
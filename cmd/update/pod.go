@@ -0,0 +1,73 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package update
+
+import (
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+)
+
+// updatePodCmd represents the update pod command
+var updatePodCmd = &cobra.Command{
+	Use:   "pod <name>",
+	Short: "Update the image, resources or settings of a pod",
+	Long: `Updates an existing pod. Only the flags you provide are changed, everything else stays as it is.
+As most settings of a pod cannot be changed while it runs, the pod is recreated with the new settings.
+The command waits until the new pod is running. If it does not start, the previous pod is restored.
+Pods of deployments and jobs cannot be updated on their own, please update the deployment with 'kufast update deployment'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
+		}
+		s := tools.CreateStandardSpinner(tools.MESSAGE_UPDATE_OBJECTS)
+
+		res := clusterOperations.UpdatePod(args[0], cmd)
+		err := <-res
+		s.Stop()
+		if err != "" {
+			tools.HandleError(errors.New(err), cmd)
+		}
+		//Warnings are sent after the result
+		for warning := range res {
+			fmt.Println(warning)
+		}
+		fmt.Println(tools.MESSAGE_DONE)
+
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	updateCmd.AddCommand(updatePodCmd)
+
+	//Settings for the pod
+	updatePodCmd.Flags().StringP("image", "i", "", "The new image of the pod.")
+	tools.AddPodFlags(updatePodCmd, false)
+
+	updatePodCmd.Flags().StringP("target", "", "", tools.DOCU_FLAG_TARGET)
+	updatePodCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+}
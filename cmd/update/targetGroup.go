@@ -33,21 +33,65 @@ import (
 
 // updateTargetGroupCmd represents the update target-group command
 var updateTargetGroupCmd = &cobra.Command{
-	Use:   "target-group <name> <nodes>..",
-	Short: "Update the nodes on an existing target group.",
-	Long: `Update the nodes on an existing target group. Specify all nodes that should be in the group after the reassignment. 
- Already existing pods on nodes will not be affected of this change.`,
+	Use:   "target-group <name> [nodes]..",
+	Short: "Update the nodes or the quota of an existing target group.",
+	Long: `Update the nodes or the quota of an existing target group. Specify all nodes that should be in the group after the reassignment. 
+Before the nodes are changed, the impact of the change is shown: the tenants with access to the group, the tenant-targets
+deploying to it and the pods running on nodes that leave the group. These pods keep running, but cannot be scheduled there
+again. The change must be confirmed, unless --force is set. Quota flags that are not set keep their current value.
+Use --revoke-access to remove the access to the group from single tenants without deleting the group.`,
 	Run: func(cmd *cobra.Command, args []string) {
 
-		if len(args) < 2 {
+		if len(args) < 1 {
 			tools.HandleError(errors.New(tools.ERROR_WRONG_NUMBER_ARGUMENTS), cmd)
 		}
 
+		force, _ := cmd.Flags().GetBool("force")
+		revokeAccess, _ := cmd.Flags().GetStringArray("revoke-access")
+
 		s := tools.CreateStandardSpinner(tools.MESSAGE_UPDATE_OBJECTS)
 
-		if clusterOperations.IsValidTarget(cmd, args[0], true) {
-			err := clusterOperations.SetTargetGroupToNodes(args[0], args[:1], cmd)
+		if !clusterOperations.IsValidTarget(cmd, args[0], true) {
+			s.Stop()
+			tools.HandleError(errors.New("Target-group "+args[0]+" does not exist."), cmd)
+		}
+
+		if len(args) > 1 {
+			impact, err := clusterOperations.GetTargetGroupImpact(args[0], args[1:], cmd)
+			if err != nil {
+				s.Stop()
+				tools.HandleError(err, cmd)
+			}
+
+			s.Stop()
+			tools.RenderTargetGroupImpact(args[0], impact)
+
+			//Ensure user knows what he does
+			if !force {
+				answer := tools.GetDialogAnswer("Target-group " + args[0] + " will be set to the nodes " + fmt.Sprint(args[1:]) + ". Continue? (yes/No)")
+				if answer != "yes" {
+					return
+				}
+			}
+			s.Start()
+
+			err = clusterOperations.UpdateTargetGroupNodes(args[0], args[1:], cmd)
+			if err != nil {
+				s.Stop()
+				tools.HandleError(err, cmd)
+			}
+		}
+
+		err := clusterOperations.SetTargetGroupQuota(args[0], cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		for _, tenant := range revokeAccess {
+			err = clusterOperations.DeleteTargetFromTenant(args[0], tenant, cmd)
 			if err != nil {
+				s.Stop()
 				tools.HandleError(err, cmd)
 			}
 		}
@@ -62,4 +106,11 @@ var updateTargetGroupCmd = &cobra.Command{
 func init() {
 	updateCmd.AddCommand(updateTargetGroupCmd)
 
+	updateTargetGroupCmd.Flags().BoolP("force", "f", false, "Change the nodes without confirmation.")
+	updateTargetGroupCmd.Flags().StringArrayP("revoke-access", "", []string{}, "A tenant whose access to the target-group "+
+		"is removed. Can be specified multiple times.")
+	updateTargetGroupCmd.Flags().StringP("memory", "", "", "Limit the RAM all tenant-targets on this group can allocate")
+	updateTargetGroupCmd.Flags().StringP("cpu", "", "", "Limit the CPU all tenant-targets on this group can allocate")
+	updateTargetGroupCmd.Flags().StringP("storage", "", "", "Limit the storage all tenant-targets on this group can allocate")
+	updateTargetGroupCmd.Flags().StringP("pods", "", "", "Limit the number of pods all tenant-targets on this group can allocate")
 }
@@ -0,0 +1,69 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package update
+
+import (
+	"fmt"
+	"github.com/spf13/cobra"
+	"kufast/clusterOperations"
+	"kufast/tools"
+)
+
+// updateTenantDomainsCmd represents the update tenant-domains command
+var updateTenantDomainsCmd = &cobra.Command{
+	Use:   "tenant-domains [domains]..",
+	Short: "Set the domains a tenant may use for its ingresses.",
+	Long: `Set the domains a tenant may use for the hostnames of its ingresses. A tenant can use the domain itself and
+all of its subdomains. The list replaces all previously allowed domains. Without any domain, the tenant can no longer
+create ingresses. The cluster denies ingresses of the tenant with other hostnames, also for ingresses applied with
+kubectl. Existing ingresses are not changed.`,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		s := tools.CreateStandardSpinner(tools.MESSAGE_UPDATE_OBJECTS)
+
+		tenantName, err := clusterOperations.GetTenantNameFromCmd(cmd)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		err = clusterOperations.SetTenantDomains(cmd, tenantName, args)
+		if err != nil {
+			s.Stop()
+			tools.HandleError(err, cmd)
+		}
+
+		s.Stop()
+		fmt.Println(tools.MESSAGE_DONE)
+	},
+}
+
+// init is a helper function from cobra to initialize the command. It sets all flags, standard values and documentation for this command.
+func init() {
+	updateCmd.AddCommand(updateTenantDomainsCmd)
+
+	updateTenantDomainsCmd.Flags().StringP("tenant", "", "", tools.DOCU_FLAG_TENANT)
+	_ = updateTenantDomainsCmd.MarkFlagRequired("tenant")
+
+}
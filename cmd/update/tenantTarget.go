@@ -29,7 +29,6 @@ import (
 	"fmt"
 	"github.com/briandowns/spinner"
 	"github.com/spf13/cobra"
-	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"kufast/clusterOperations"
 	"kufast/objectFactory"
@@ -83,64 +82,111 @@ var updateTenantTargetCmd = &cobra.Command{
 			tools.HandleError(err, cmd)
 		}
 
-		//Get Networkpolicy for namespace
-		nps, err := clientset.NetworkingV1().NetworkPolicies(tenantTargetName).List(context.TODO(), metav1.ListOptions{})
+		ram, _ := cmd.Flags().GetString("memory")
+		cpu, _ := cmd.Flags().GetString("cpu")
+		storage, _ := cmd.Flags().GetString("storage")
+		ramRequest, _ := cmd.Flags().GetString("memory-request")
+		cpuRequest, _ := cmd.Flags().GetString("cpu-request")
+		maxRatio, _ := cmd.Flags().GetString("max-limit-request-ratio")
+		placement, _ := cmd.Flags().GetString("placement")
+		security, _ := cmd.Flags().GetString("security")
+		services, _ := cmd.Flags().GetString("services")
+		nodePorts, _ := cmd.Flags().GetString("nodeports")
+		configMaps, _ := cmd.Flags().GetString("configmaps")
+		volumes, _ := cmd.Flags().GetString("volumes")
+		storageClasses, _ := cmd.Flags().GetStringArray("storage-class")
+		nodeSelectorTarget, _ := cmd.Flags().GetString("target")
+
+		if !tools.IsValidLimitRequestRatio(maxRatio) {
+			s.Stop()
+			tools.HandleError(errors.New(tools.ERROR_INVALID_LIMIT_REQUEST_RATIO), cmd)
+		}
+
+		//Override the node selector of the tenant-target, so its pods are scheduled on the nodes of another target
+		if cmd.Flags().Changed("target") {
+			newTarget, err := clusterOperations.GetTargetFromTargetName(cmd, nodeSelectorTarget, tenantName, true)
+			if err != nil {
+				s.Stop()
+				tools.HandleError(err, cmd)
+			}
+			objectFactory.SetNamespaceNodeSelector(namespace, newTarget)
+		}
+
+		objectFactory.UpdateResourceQuota(quota, ram, cpu, ramRequest, cpuRequest, storage, services, nodePorts, configMaps, volumes)
+
+		//Ensure the tenant-target still fits into the quota of its target-group
+		target, err := clusterOperations.GetTargetFromTenantTarget(namespace)
+		if err == nil && target.AccessType == "group" {
+			err = clusterOperations.CheckTargetGroupQuota(target.Name, tenantTargetName, quota.Spec.Hard, cmd)
+			if err != nil {
+				s.Stop()
+				tools.HandleError(err, cmd)
+			}
+		}
+
+		//Ensure the network policies are up to date
+		err = clusterOperations.UpdateTenantTargetNetworkPolicies(tenantTargetName, tenantName, clusterOperations.QuotaAllowsNodePorts(quota), cmd)
 		if err != nil {
 			s.Stop()
 			tools.HandleError(err, cmd)
 		}
 
-		ram, _ := cmd.Flags().GetString("memory")
-		cpu, _ := cmd.Flags().GetString("cpu")
-		storage, _ := cmd.Flags().GetString("storage")
-		target, _ := cmd.Flags().GetString("target")
+		//Create current role scheme to update namespace
+		role := objectFactory.NewRole(tenantTargetName)
 
-		if namespace.ObjectMeta.Annotations == nil {
-			//No annotations have been provided, need to create them
-			annotations := map[string]string{}
-			namespace.ObjectMeta.Annotations = annotations
+		//Apply changes
+		if cmd.Flags().Changed("placement") {
+			err = clusterOperations.SetTenantTargetPlacement(cmd, tenantName, args[0], placement)
+			if err != nil {
+				s.Stop()
+				tools.HandleError(err, cmd)
+			}
 		}
-		namespace.ObjectMeta.Annotations["scheduler.alpha.kubernetes.io/node-selector"] = "target=" + target
 
-		if ram != "" {
-			qty, err := resource.ParseQuantity(ram)
-			if err == nil {
-				quota.Spec.Hard["limits.memory"] = qty
-				quota.Spec.Hard["requests.memory"] = qty
+		if cmd.Flags().Changed("security") {
+			err = clusterOperations.SetTenantTargetSecurityLevel(cmd, tenantName, args[0], security)
+			if err != nil {
+				s.Stop()
+				tools.HandleError(err, cmd)
 			}
+			objectFactory.SetPodSecurityLevel(namespace, security)
 		}
-		if cpu != "" {
-			qty, err := resource.ParseQuantity(cpu)
-			if err == nil {
-				quota.Spec.Hard["limits.cpu"] = qty
-				quota.Spec.Hard["requests.cpu"] = qty
+
+		if cmd.Flags().Changed("max-limit-request-ratio") {
+			limitRange, err := clientset.CoreV1().LimitRanges(tenantTargetName).Get(context.TODO(), tenantTargetName+"-limitrange", metav1.GetOptions{})
+			if err != nil {
+				s.Stop()
+				tools.HandleError(err, cmd)
+			}
+			objectFactory.SetMaxLimitRequestRatio(limitRange, maxRatio)
+			_, err = clientset.CoreV1().LimitRanges(tenantTargetName).Update(context.TODO(), limitRange, metav1.UpdateOptions{})
+			if err != nil {
+				s.Stop()
+				tools.HandleError(err, cmd)
 			}
 		}
 
-		if storage != "" {
-			qty, err := resource.ParseQuantity(storage)
-			if err == nil {
-				quota.Spec.Hard["limits.ephemeral-storage"] = qty
-				quota.Spec.Hard["requests.ephemeral-storage"] = qty
+		if cmd.Flags().Changed("storage-class") {
+			err = clusterOperations.SetTenantTargetStorageClasses(cmd, tenantName, args[0], storageClasses)
+			if err != nil {
+				s.Stop()
+				tools.HandleError(err, cmd)
+			}
+		} else {
+			storageClasses, err = clusterOperations.GetTenantTargetStorageClasses(cmd, tenantName, args[0])
+			if err != nil {
+				s.Stop()
+				tools.HandleError(err, cmd)
 			}
 		}
 
-		if len(nps.Items) == 0 {
-			//Keine Policy, erstelle Policy
-			_, _ = clientset.NetworkingV1().NetworkPolicies(tenantTargetName).Create(context.TODO(), objectFactory.NewNetworkPolicy(args[0], tenantName), metav1.CreateOptions{})
-		} else if len(nps.Items) == 1 {
-			//Update Policy
-			_, _ = clientset.NetworkingV1().NetworkPolicies(tenantTargetName).Update(context.TODO(), objectFactory.NewNetworkPolicy(args[0], tenantName), metav1.UpdateOptions{})
-		} else {
+		//Let Kubernetes reject volumes of other storage classes, also for storage classes added since the last update
+		err = clusterOperations.SetStorageClassQuota(cmd, quota, storageClasses)
+		if err != nil {
 			s.Stop()
-			fmt.Println("More than one Network policy detected! ignoring..")
-			s.Start()
+			tools.HandleError(err, cmd)
 		}
 
-		//Create current role scheme to update namespace
-		role := objectFactory.NewRole(tenantTargetName)
-
-		//Apply changes
 		_, err = clientset.CoreV1().ResourceQuotas(tenantTargetName).Update(context.TODO(), quota, metav1.UpdateOptions{})
 		if err != nil {
 			s.Stop()
@@ -168,9 +214,25 @@ var updateTenantTargetCmd = &cobra.Command{
 func init() {
 	updateCmd.AddCommand(updateTenantTargetCmd)
 
-	updateTenantTargetCmd.Flags().StringP("memory", "", "", "Limit the RAM usage for this namespace")
-	updateTenantTargetCmd.Flags().StringP("cpu", "", "", "Limit the CPU usage for this namespace")
+	updateTenantTargetCmd.Flags().StringP("memory", "", "", "Limit the RAM usage for this namespace. The RAM requests are only changed by --memory-request.")
+	updateTenantTargetCmd.Flags().StringP("cpu", "", "", "Limit the CPU usage for this namespace. The CPU requests are only changed by --cpu-request.")
+	updateTenantTargetCmd.Flags().StringP("memory-request", "", "", "Limit the total RAM requests for this namespace")
+	updateTenantTargetCmd.Flags().StringP("cpu-request", "", "", "Limit the total CPU requests for this namespace")
+	updateTenantTargetCmd.Flags().StringP("max-limit-request-ratio", "", "", "Maximum ratio between the limits and requests "+
+		"of CPU and memory of a container in this namespace, e.g. 2. An empty value removes the restriction.")
 	updateTenantTargetCmd.Flags().StringP("storage", "", "", "Limit the storage usage for this namespace")
+	updateTenantTargetCmd.Flags().StringP("services", "", "", "Limit the number of services for this namespace")
+	updateTenantTargetCmd.Flags().StringP("nodeports", "", "", "Limit the number of services for this namespace that are reachable from outside through a NodePort")
+	updateTenantTargetCmd.Flags().StringP("configmaps", "", "", "Limit the number of configmaps for this namespace")
+	updateTenantTargetCmd.Flags().StringP("volumes", "", "", "Limit the number of volumes for this namespace")
+	updateTenantTargetCmd.Flags().StringArrayP("storage-class", "", []string{}, "A storage class the tenant may use for volumes. "+
+		"Replaces all previously allowed storage classes. The quota of all other storage classes is set to zero. "+
+		"Can be specified multiple times.")
+	updateTenantTargetCmd.Flags().StringP("placement", "", "", "Placement policy for new pods on group targets (spread, pack or none)")
+	updateTenantTargetCmd.Flags().StringP("security", "", "", "Pod security level for new pods (restricted, baseline or privileged). "+
+		"Running pods are not affected.")
+	updateTenantTargetCmd.Flags().StringP("target", "", "", "Override the node selector of the tenant-target with the nodes of "+
+		"another target. Only new pods are scheduled on these nodes.")
 	updateTenantTargetCmd.Flags().StringP("tenant", "t", "", tools.DOCU_FLAG_TENANT)
 	_ = updateTenantTargetCmd.MarkFlagRequired("tenant")
 
@@ -8,8 +8,13 @@ import (
 )
 import c "kufast/cmd/create"
 import d "kufast/cmd/delete"
+import e "kufast/cmd/explain"
 import g "kufast/cmd/get"
+import h "kufast/cmd/history"
+import i "kufast/cmd/imports"
 import l "kufast/cmd/list"
+import m "kufast/cmd/maintenance"
+import r "kufast/cmd/rollback"
 import u "kufast/cmd/update"
 
 func main() {
@@ -36,9 +41,17 @@ func generateMdDocs() {
 
 	cmd.CreateRootDocs(linkHandler)
 	cmd.CreateExecDocs(linkHandler)
+	cmd.CreateExposeDocs(linkHandler)
+	cmd.CreateApplyManifestDocs(linkHandler)
+	cmd.CreateStatusDocs(linkHandler)
 	c.CreateCreateDocs(filePrepander, linkHandler)
 	d.CreateDeleteDocs(filePrepander, linkHandler)
 	g.CreateGetDocs(filePrepander, linkHandler)
 	l.CreateListDocs(filePrepander, linkHandler)
 	u.CreateUpdateDocs(filePrepander, linkHandler)
+	m.CreateMaintenanceDocs(filePrepander, linkHandler)
+	h.CreateHistoryDocs(filePrepander, linkHandler)
+	r.CreateRollbackDocs(filePrepander, linkHandler)
+	i.CreateImportDocs(filePrepander, linkHandler)
+	e.CreateExplainDocs(filePrepander, linkHandler)
 }
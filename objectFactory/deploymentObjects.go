@@ -24,15 +24,22 @@ SOFTWARE.
 package objectFactory
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	n1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"kufast/tools"
+	"strconv"
+	"strings"
 )
 
 // NewPod creates a new Kubernetes pod object based on several parameters.
 // Created objects only exist locally and need to be deployed to the cluster.
 func NewPod(podName string, imageName string, namespaceName string,
-	attachedSecrets []string, deploySecret string, cpu string, ram string, storage string, shouldRestart bool, ports []int32, command []string) *v1.Pod {
+	attachedSecrets []string, deploySecret string, cpu string, ram string, storage string, restartPolicy v1.RestartPolicy, ports []int32, command []string) *v1.Pod {
 
 	var newPod *v1.Pod
 	newPod = &v1.Pod{
@@ -44,63 +51,360 @@ func NewPod(podName string, imageName string, namespaceName string,
 			Name:      podName,
 			Namespace: namespaceName,
 			Labels: map[string]string{
-				"network": namespaceName,
+				"network":                    namespaceName,
+				tools.KUFAST_TENANT_LABEL:    tools.GetTenantFromNamespace(namespaceName),
+				tools.KUFAST_KEEPALIVE_LABEL: strconv.FormatBool(restartPolicy == v1.RestartPolicyAlways),
+				tools.KUFAST_WORKLOAD_LABEL:  podName,
 			},
 		},
 		Spec: v1.PodSpec{
 			Containers: []v1.Container{
+				NewContainer(podName, imageName, command, cpu, ram, storage, ports, attachedSecrets),
+			},
+		},
+		Status: v1.PodStatus{},
+	}
+
+	newPod.Spec.RestartPolicy = restartPolicy
+
+	newPod.Spec.ImagePullSecrets = NewImagePullSecrets(deploySecret)
+
+	return newPod
+
+}
+
+// NewContainer creates a new Kubernetes container object based on several parameters. It can be used as main
+// container, sidecar or init container of a pod.
+func NewContainer(containerName string, imageName string, command []string, cpu string, ram string, storage string,
+	ports []int32, attachedSecrets []string) v1.Container {
+	container := v1.Container{
+		Name:    containerName,
+		Image:   imageName,
+		Command: command,
+		Resources: v1.ResourceRequirements{
+			Limits:   v1.ResourceList{},
+			Requests: v1.ResourceList{},
+		},
+		Ports: NewContainerPorts(ports),
+		Env:   NewSecretEnvVars(attachedSecrets),
+	}
+	SetContainerResources(&container, cpu, ram, storage)
+
+	return container
+}
+
+// NewDeployment creates a new Kubernetes deployment object based on several parameters. The pod template is taken
+// from a pod object, e.g. created by NewPod. Pods of a deployment are always restarted upon termination.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewDeployment(deploymentName string, namespaceName string, replicas int32, pod *v1.Pod) *appsv1.Deployment {
+	newDeployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deploymentName,
+			Namespace: namespaceName,
+			Labels: map[string]string{
+				tools.KUFAST_TENANT_LABEL: tools.GetTenantFromNamespace(namespaceName),
+			},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					tools.KUFAST_WORKLOAD_LABEL: deploymentName,
+				},
+			},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: pod.ObjectMeta.Labels,
+				},
+				Spec: *pod.Spec.DeepCopy(),
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+			},
+		},
+	}
+	newDeployment.Spec.Template.ObjectMeta.Labels[tools.KUFAST_WORKLOAD_LABEL] = deploymentName
+	newDeployment.Spec.Template.Spec.RestartPolicy = v1.RestartPolicyAlways
+
+	return newDeployment
+}
+
+// NewJob creates a new Kubernetes job object based on several parameters. The pod template is taken from a pod
+// object, e.g. created by NewPod. Failed pods are not restarted but replaced, until the backoff limit is reached.
+// A negative ttl keeps finished jobs until they are deleted manually.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewJob(jobName string, namespaceName string, completions int32, parallelism int32, backoffLimit int32, ttl int32, pod *v1.Pod) *batchv1.Job {
+	newJob := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName,
+			Namespace: namespaceName,
+			Labels: map[string]string{
+				tools.KUFAST_TENANT_LABEL: tools.GetTenantFromNamespace(namespaceName),
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Completions:  &completions,
+			Parallelism:  &parallelism,
+			BackoffLimit: &backoffLimit,
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: pod.ObjectMeta.Labels,
+				},
+				Spec: *pod.Spec.DeepCopy(),
+			},
+		},
+	}
+	if ttl >= 0 {
+		newJob.Spec.TTLSecondsAfterFinished = &ttl
+	}
+	newJob.Spec.Template.ObjectMeta.Labels[tools.KUFAST_WORKLOAD_LABEL] = jobName
+	newJob.Spec.Template.Spec.RestartPolicy = v1.RestartPolicyNever
+
+	return newJob
+}
+
+// NewCronJob creates a new Kubernetes cronjob object based on several parameters. The job template is taken from a
+// job object, e.g. created by NewJob. Runs of a cronjob never overlap.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewCronJob(cronJobName string, namespaceName string, schedule string, job *batchv1.Job) *batchv1.CronJob {
+	return &batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "CronJob",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cronJobName,
+			Namespace: namespaceName,
+			Labels: map[string]string{
+				tools.KUFAST_TENANT_LABEL: tools.GetTenantFromNamespace(namespaceName),
+			},
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:          schedule,
+			ConcurrencyPolicy: batchv1.ForbidConcurrent,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: job.ObjectMeta.Labels,
+				},
+				Spec: *job.Spec.DeepCopy(),
+			},
+		},
+	}
+}
+
+// NewService creates a new Kubernetes service object based on several parameters. The service selects all pods of a
+// workload (pod or deployment) and forwards each port to the same port of the pods. Supported service types are
+// ClusterIP and NodePort.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewService(serviceName string, namespaceName string, workloadName string, serviceType v1.ServiceType, ports []int32) *v1.Service {
+	newService := &v1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: namespaceName,
+			Labels: map[string]string{
+				tools.KUFAST_TENANT_LABEL:   tools.GetTenantFromNamespace(namespaceName),
+				tools.KUFAST_WORKLOAD_LABEL: workloadName,
+			},
+		},
+		Spec: v1.ServiceSpec{
+			Type: serviceType,
+			Selector: map[string]string{
+				tools.KUFAST_WORKLOAD_LABEL: workloadName,
+			},
+			Ports: []v1.ServicePort{},
+		},
+	}
+
+	for _, port := range ports {
+		newService.Spec.Ports = append(newService.Spec.Ports, v1.ServicePort{
+			Name:       "port-" + strconv.Itoa(int(port)),
+			Port:       port,
+			TargetPort: intstr.FromInt(int(port)),
+		})
+	}
+
+	return newService
+}
+
+// NewIngress creates a new Kubernetes ingress object based on several parameters. The ingress routes all requests for
+// a hostname and path prefix to a port of a service. If a TLS secret is given, the hostname is served via HTTPS.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewIngress(ingressName string, namespaceName string, hostname string, path string, serviceName string,
+	servicePort int32, tlsSecret string, ingressClass string) *n1.Ingress {
+	pathType := n1.PathTypePrefix
+	newIngress := &n1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ingressName,
+			Namespace: namespaceName,
+			Labels: map[string]string{
+				tools.KUFAST_TENANT_LABEL: tools.GetTenantFromNamespace(namespaceName),
+			},
+		},
+		Spec: n1.IngressSpec{
+			Rules: []n1.IngressRule{
 				{
-					Name:    podName,
-					Image:   imageName,
-					Command: command,
-					Resources: v1.ResourceRequirements{
-						Limits:   v1.ResourceList{},
-						Requests: v1.ResourceList{},
+					Host: hostname,
+					IngressRuleValue: n1.IngressRuleValue{
+						HTTP: &n1.HTTPIngressRuleValue{
+							Paths: []n1.HTTPIngressPath{
+								{
+									Path:     path,
+									PathType: &pathType,
+									Backend: n1.IngressBackend{
+										Service: &n1.IngressServiceBackend{
+											Name: serviceName,
+											Port: n1.ServiceBackendPort{
+												Number: servicePort,
+											},
+										},
+									},
+								},
+							},
+						},
 					},
-					Ports: []v1.ContainerPort{},
-					Env:   []v1.EnvVar{},
 				},
 			},
 		},
-		Status: v1.PodStatus{},
 	}
 
+	if tlsSecret != "" {
+		newIngress.Spec.TLS = []n1.IngressTLS{
+			{
+				Hosts:      []string{hostname},
+				SecretName: tlsSecret,
+			},
+		}
+	}
+	if ingressClass != "" {
+		newIngress.Spec.IngressClassName = &ingressClass
+	}
+
+	return newIngress
+}
+
+// SetContainerResources sets the limits and requests of a container to the same values. Only non-empty values are set,
+// so the function can also be used to update single resources of an existing container.
+func SetContainerResources(container *v1.Container, cpu string, ram string, storage string) {
+	SetContainerLimits(container, cpu, ram, storage)
+	SetContainerRequests(container, cpu, ram, storage)
+}
+
+// SetContainerLimits sets the limits of a container. Only non-empty values are set.
+func SetContainerLimits(container *v1.Container, cpu string, ram string, storage string) {
+	if container.Resources.Limits == nil {
+		container.Resources.Limits = v1.ResourceList{}
+	}
+	setResourceList(container.Resources.Limits, cpu, ram, storage)
+}
+
+// SetContainerRequests sets the requests of a container. Only non-empty values are set. Requests lower than the
+// limits make the pod burstable instead of guaranteed.
+func SetContainerRequests(container *v1.Container, cpu string, ram string, storage string) {
+	if container.Resources.Requests == nil {
+		container.Resources.Requests = v1.ResourceList{}
+	}
+	setResourceList(container.Resources.Requests, cpu, ram, storage)
+}
+
+// setResourceList sets CPU, memory and ephemeral storage in a resource list, if they are non-empty and valid.
+func setResourceList(list v1.ResourceList, cpu string, ram string, storage string) {
 	if ram != "" {
 		qty, err := resource.ParseQuantity(ram)
 		if err == nil {
-			newPod.Spec.Containers[0].Resources.Limits["memory"] = qty
-			newPod.Spec.Containers[0].Resources.Requests["memory"] = qty
+			list["memory"] = qty
 		}
 	}
 	if cpu != "" {
 		qty, err := resource.ParseQuantity(cpu)
 		if err == nil {
-			newPod.Spec.Containers[0].Resources.Limits["cpu"] = qty
-			newPod.Spec.Containers[0].Resources.Requests["cpu"] = qty
+			list["cpu"] = qty
 		}
 	}
 
 	if storage != "" {
 		qty, err := resource.ParseQuantity(storage)
 		if err == nil {
-			newPod.Spec.Containers[0].Resources.Limits["ephemeral-storage"] = qty
-			newPod.Spec.Containers[0].Resources.Requests["ephemeral-storage"] = qty
+			list["ephemeral-storage"] = qty
 		}
 	}
+}
+
+// SetRunAsUser sets the user ID the processes of a container are run with. A negative ID removes the setting, so the
+// user of the image is used.
+func SetRunAsUser(container *v1.Container, user int64) {
+	if user < 0 {
+		if container.SecurityContext != nil {
+			container.SecurityContext.RunAsUser = nil
+		}
+		return
+	}
+	if container.SecurityContext == nil {
+		container.SecurityContext = &v1.SecurityContext{}
+	}
+	container.SecurityContext.RunAsUser = &user
+}
+
+// NewProbe creates a new Kubernetes probe object based on several parameters. Supported kinds are http (port and path),
+// tcp (port) and exec (the command is passed as path and run within a shell).
+func NewProbe(kind string, port int32, path string, delay int32, period int32, failures int32) *v1.Probe {
+	probe := &v1.Probe{
+		InitialDelaySeconds: delay,
+		PeriodSeconds:       period,
+		FailureThreshold:    failures,
+	}
 
-	if shouldRestart {
-		newPod.Spec.RestartPolicy = v1.RestartPolicyAlways
+	switch kind {
+	case "http":
+		probe.HTTPGet = &v1.HTTPGetAction{
+			Path: path,
+			Port: intstr.FromInt(int(port)),
+		}
+	case "tcp":
+		probe.TCPSocket = &v1.TCPSocketAction{
+			Port: intstr.FromInt(int(port)),
+		}
+	case "exec":
+		probe.Exec = &v1.ExecAction{
+			Command: []string{"sh", "-c", path},
+		}
 	}
 
+	return probe
+}
+
+// NewContainerPorts creates the port list of a container from a list of port numbers.
+func NewContainerPorts(ports []int32) []v1.ContainerPort {
+	containerPorts := []v1.ContainerPort{}
 	for _, port := range ports {
-		containerPort := v1.ContainerPort{
+		containerPorts = append(containerPorts, v1.ContainerPort{
 			ContainerPort: port,
-		}
-		newPod.Spec.Containers[0].Ports = append(newPod.Spec.Containers[0].Ports, containerPort)
+		})
 	}
+	return containerPorts
+}
 
+// NewSecretEnvVars creates environment variables from kufast secrets. The name of each variable equals the name of
+// its secret.
+func NewSecretEnvVars(attachedSecrets []string) []v1.EnvVar {
+	envVars := []v1.EnvVar{}
 	for _, secretName := range attachedSecrets {
-		newPod.Spec.Containers[0].Env = append(newPod.Spec.Containers[0].Env, v1.EnvVar{
+		envVars = append(envVars, v1.EnvVar{
 			Name: secretName,
 			ValueFrom: &v1.EnvVarSource{
 				SecretKeyRef: &v1.SecretKeySelector{
@@ -112,17 +416,378 @@ func NewPod(podName string, imageName string, namespaceName string,
 			},
 		})
 	}
+	return envVars
+}
+
+// SetSecretEnvVars replaces the environment variables of a container that have been created from kufast secrets.
+// All other environment variables stay untouched.
+func SetSecretEnvVars(container *v1.Container, attachedSecrets []string) {
+	var envVars []v1.EnvVar
+	for _, envVar := range container.Env {
+		isSecretVar := envVar.ValueFrom != nil && envVar.ValueFrom.SecretKeyRef != nil &&
+			envVar.ValueFrom.SecretKeyRef.Name == envVar.Name && envVar.ValueFrom.SecretKeyRef.Key == "secret"
+		if !isSecretVar {
+			envVars = append(envVars, envVar)
+		}
+	}
+	container.Env = append(envVars, NewSecretEnvVars(attachedSecrets)...)
+}
+
+// SetEnvVar sets a plain environment variable of a container. An existing variable with the same name is replaced.
+func SetEnvVar(container *v1.Container, name string, value string) {
+	for i := range container.Env {
+		if container.Env[i].Name == name {
+			container.Env[i] = v1.EnvVar{Name: name, Value: value}
+			return
+		}
+	}
+	container.Env = append(container.Env, v1.EnvVar{Name: name, Value: value})
+}
+
+// SetSecretKeyEnvVar sets an environment variable of a container to the value of a key of a secret. An existing
+// variable with the same name is replaced.
+func SetSecretKeyEnvVar(container *v1.Container, name string, secretName string, key string) {
+	envVar := v1.EnvVar{
+		Name: name,
+		ValueFrom: &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key: key,
+			},
+		},
+	}
+	for i := range container.Env {
+		if container.Env[i].Name == name {
+			container.Env[i] = envVar
+			return
+		}
+	}
+	container.Env = append(container.Env, envVar)
+}
+
+// SetConfigMapEnvSources replaces the configmaps of a container, whose keys are all introduced as environment
+// variables. Other sources of environment variables stay untouched.
+func SetConfigMapEnvSources(container *v1.Container, configMaps []string) {
+	var envSources []v1.EnvFromSource
+	for _, envSource := range container.EnvFrom {
+		if envSource.ConfigMapRef == nil {
+			envSources = append(envSources, envSource)
+		}
+	}
+	for _, configMapName := range configMaps {
+		envSources = append(envSources, v1.EnvFromSource{
+			ConfigMapRef: &v1.ConfigMapEnvSource{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: configMapName,
+				},
+			},
+		})
+	}
+	container.EnvFrom = envSources
+}
+
+// SetConfigMapVolumes replaces the configmaps mounted into a container. Every key of a configmap becomes a file in its
+// mount path. configMaps and mountPaths are matched by their index.
+func SetConfigMapVolumes(podSpec *v1.PodSpec, container *v1.Container, configMaps []string, mountPaths []string) {
+	var volumes []v1.Volume
+	for _, configMapName := range configMaps {
+		volumes = append(volumes, v1.Volume{
+			Name: "configmap-" + configMapName,
+			VolumeSource: v1.VolumeSource{
+				ConfigMap: &v1.ConfigMapVolumeSource{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: configMapName,
+					},
+				},
+			},
+		})
+	}
+	setVolumes(podSpec, container, "configmap-", volumes, mountPaths, true)
+}
+
+// SetSecretVolumes replaces the secrets mounted into a container. Every key of a secret becomes a read-only file in
+// its mount path. secrets and mountPaths are matched by their index.
+func SetSecretVolumes(podSpec *v1.PodSpec, container *v1.Container, secrets []string, mountPaths []string) {
+	var volumes []v1.Volume
+	for _, secretName := range secrets {
+		volumes = append(volumes, v1.Volume{
+			Name: "secret-" + secretName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: secretName,
+				},
+			},
+		})
+	}
+	setVolumes(podSpec, container, "secret-", volumes, mountPaths, true)
+}
+
+// SetPersistentVolumes replaces the volumes mounted into a container. Data written to the mount path outlives the
+// container. volumes and mountPaths are matched by their index.
+func SetPersistentVolumes(podSpec *v1.PodSpec, container *v1.Container, persistentVolumes []string, mountPaths []string) {
+	var volumes []v1.Volume
+	for _, volumeName := range persistentVolumes {
+		volumes = append(volumes, v1.Volume{
+			Name: "volume-" + volumeName,
+			VolumeSource: v1.VolumeSource{
+				PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+					ClaimName: volumeName,
+				},
+			},
+		})
+	}
+	setVolumes(podSpec, container, "volume-", volumes, mountPaths, false)
+}
+
+// setVolumes replaces all volumes of a pod spec and their mounts in a container, whose names start with the prefix.
+// volumes and mountPaths are matched by their index.
+func setVolumes(podSpec *v1.PodSpec, container *v1.Container, prefix string, newVolumes []v1.Volume, mountPaths []string, readOnly bool) {
+	var volumes []v1.Volume
+	for _, volume := range podSpec.Volumes {
+		if !strings.HasPrefix(volume.Name, prefix) {
+			volumes = append(volumes, volume)
+		}
+	}
+	var mounts []v1.VolumeMount
+	for _, mount := range container.VolumeMounts {
+		if !strings.HasPrefix(mount.Name, prefix) {
+			mounts = append(mounts, mount)
+		}
+	}
+
+	for i, volume := range newVolumes {
+		volumes = append(volumes, volume)
+		mounts = append(mounts, v1.VolumeMount{
+			Name:      volume.Name,
+			MountPath: mountPaths[i],
+			ReadOnly:  readOnly,
+		})
+	}
+
+	podSpec.Volumes = volumes
+	container.VolumeMounts = mounts
+}
+
+// NewImagePullSecrets creates the image pull secret list of a pod from a deploy-secret. Returns nil, if no
+// deploy-secret is given.
+func NewImagePullSecrets(deploySecret string) []v1.LocalObjectReference {
+	if deploySecret == "" {
+		return nil
+	}
+	return []v1.LocalObjectReference{
+		{
+			Name: deploySecret,
+		},
+	}
+}
+
+// ApplyPlacementPolicy adds scheduling rules for the placement policy of a tenant-target to a pod spec. With "spread"
+// the pods of a tenant are distributed evenly over the nodes of a target, with "pack" they are preferably placed on
+// the same node. Any other policy leaves the pod spec untouched.
+func ApplyPlacementPolicy(podSpec *v1.PodSpec, tenantName string, policy string) {
+	tenantSelector := &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			tools.KUFAST_TENANT_LABEL: tenantName,
+		},
+	}
 
-	if deploySecret != "" {
-		newPod.Spec.ImagePullSecrets = []v1.LocalObjectReference{
+	switch policy {
+	case "spread":
+		podSpec.TopologySpreadConstraints = []v1.TopologySpreadConstraint{
 			{
-				Name: deploySecret,
+				MaxSkew:           1,
+				TopologyKey:       tools.KUFAST_NODE_HOSTNAME_LABEL,
+				WhenUnsatisfiable: v1.ScheduleAnyway,
+				LabelSelector:     tenantSelector,
 			},
 		}
+	case "pack":
+		podSpec.Affinity = &v1.Affinity{
+			PodAffinity: &v1.PodAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []v1.WeightedPodAffinityTerm{
+					{
+						Weight: 100,
+						PodAffinityTerm: v1.PodAffinityTerm{
+							LabelSelector: tenantSelector,
+							TopologyKey:   tools.KUFAST_NODE_HOSTNAME_LABEL,
+						},
+					},
+				},
+			},
+		}
+	}
+}
+
+// ApplySecurityLevel adds the security settings a pod needs to comply with a level of the Kubernetes Pod Security
+// Standards to a pod spec. With "restricted" pods run as non-root user without privilege escalation, with all
+// capabilities dropped and the RuntimeDefault seccomp profile, with "baseline" only the seccomp profile is set.
+// Settings already present in the pod spec are kept, so the admission of the namespace reports conflicting ones.
+func ApplySecurityLevel(podSpec *v1.PodSpec, level string) {
+	if level != "baseline" && level != "restricted" {
+		return
+	}
+
+	if podSpec.SecurityContext == nil {
+		podSpec.SecurityContext = &v1.PodSecurityContext{}
+	}
+	if podSpec.SecurityContext.SeccompProfile == nil {
+		podSpec.SecurityContext.SeccompProfile = &v1.SeccompProfile{Type: v1.SeccompProfileTypeRuntimeDefault}
+	}
+	if level != "restricted" {
+		return
+	}
+
+	if podSpec.SecurityContext.RunAsNonRoot == nil {
+		runAsNonRoot := true
+		podSpec.SecurityContext.RunAsNonRoot = &runAsNonRoot
+	}
+	for _, containers := range [][]v1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			if containers[i].SecurityContext == nil {
+				containers[i].SecurityContext = &v1.SecurityContext{}
+			}
+			if containers[i].SecurityContext.AllowPrivilegeEscalation == nil {
+				allowPrivilegeEscalation := false
+				containers[i].SecurityContext.AllowPrivilegeEscalation = &allowPrivilegeEscalation
+			}
+			if containers[i].SecurityContext.Capabilities == nil {
+				containers[i].SecurityContext.Capabilities = &v1.Capabilities{Drop: []v1.Capability{"ALL"}}
+			}
+		}
+	}
+}
+
+// NewTargetNodeSelector creates the node selector, which restricts pods to the nodes of a target.
+func NewTargetNodeSelector(target tools.Target) map[string]string {
+	if target.AccessType == "node" {
+		return map[string]string{tools.KUFAST_NODE_HOSTNAME_LABEL: target.Name}
+	}
+	return map[string]string{tools.KUFAST_NODE_GROUP_LABEL + target.Name: "true"}
+}
+
+// ConstrainPodTemplate adds the labels and the node selector of a tenant-target, that NewPod adds to pods, to the
+// metadata and spec of a pod or pod template created elsewhere, e.g. from a manifest.
+func ConstrainPodTemplate(podMeta *metav1.ObjectMeta, podSpec *v1.PodSpec, namespaceName string, workloadName string,
+	target tools.Target) {
+	if podMeta.Labels == nil {
+		podMeta.Labels = map[string]string{}
+	}
+	podMeta.Labels["network"] = namespaceName
+	podMeta.Labels[tools.KUFAST_TENANT_LABEL] = tools.GetTenantFromNamespace(namespaceName)
+	if podMeta.Labels[tools.KUFAST_WORKLOAD_LABEL] == "" {
+		podMeta.Labels[tools.KUFAST_WORKLOAD_LABEL] = workloadName
+	}
+
+	if podSpec.NodeSelector == nil {
+		podSpec.NodeSelector = map[string]string{}
+	}
+	for key, value := range NewTargetNodeSelector(target) {
+		podSpec.NodeSelector[key] = value
+	}
+}
+
+// NewPodFromPod creates a new Kubernetes pod object from an existing pod. Status and node assignment of the
+// existing pod are dropped, so the scheduler can place the new pod again.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewPodFromPod(pod *v1.Pod) *v1.Pod {
+	newPod := &v1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Pod",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pod.Name,
+			Namespace:   pod.Namespace,
+			Labels:      pod.ObjectMeta.Labels,
+			Annotations: pod.ObjectMeta.Annotations,
+		},
+		Spec:   *pod.Spec.DeepCopy(),
+		Status: v1.PodStatus{},
 	}
+	newPod.Spec.NodeName = ""
 
 	return newPod
+}
+
+// NewPersistentVolumeClaim creates a new Kubernetes PersistentVolumeClaim object based on several parameters. Without a
+// storage class, the default storage class of the cluster is used.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewPersistentVolumeClaim(namespaceName string, volumeName string, size resource.Quantity, storageClass string,
+	accessMode v1.PersistentVolumeAccessMode) *v1.PersistentVolumeClaim {
+	newClaim := &v1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      volumeName,
+			Namespace: namespaceName,
+			Labels: map[string]string{
+				tools.KUFAST_TENANT_LABEL: tools.GetTenantFromNamespace(namespaceName),
+			},
+		},
+		Spec: v1.PersistentVolumeClaimSpec{
+			AccessModes: []v1.PersistentVolumeAccessMode{accessMode},
+			Resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{
+					v1.ResourceStorage: size,
+				},
+			},
+		},
+	}
+	if storageClass != "" {
+		newClaim.Spec.StorageClassName = &storageClass
+	}
+
+	return newClaim
+}
+
+// NewConfigMap creates a new Kubernetes configmap object based on several parameters.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewConfigMap(namespaceName string, configMapName string, data map[string]string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      configMapName,
+			Namespace: namespaceName,
+			Labels: map[string]string{
+				tools.KUFAST_TENANT_LABEL: tools.GetTenantFromNamespace(namespaceName),
+			},
+		},
+		Data: data,
+	}
+}
 
+// NewProfilesConfigMap creates a new Kubernetes configmap object, which holds the pod profiles shared within a
+// tenant-target. The configmap is labeled, so it is not listed among the configmaps of the tenant.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewProfilesConfigMap(namespaceName string) *v1.ConfigMap {
+	profiles := NewConfigMap(namespaceName, tools.KUFAST_PROFILES_CONFIGMAP, map[string]string{})
+	profiles.Labels[tools.KUFAST_PROFILES_LABEL] = "true"
+	return profiles
+}
+
+// NewPodFromRevision creates a new Kubernetes pod object from a revision of the revision history of a pod.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewPodFromRevision(podName string, namespaceName string, revision tools.PodRevision) *v1.Pod {
+	return &v1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Pod",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: namespaceName,
+			Labels:    revision.Labels,
+		},
+		Spec:   *revision.Spec.DeepCopy(),
+		Status: v1.PodStatus{},
+	}
 }
 
 // NewSecret creates a new Kubernetes secret object based on several parameters.
@@ -144,6 +809,24 @@ func NewSecret(namespaceName string, secretName string, secretData string) *v1.S
 	}
 }
 
+// NewMultiKeySecret creates a new Kubernetes secret object with several keys based on several parameters. Each key
+// can be introduced into a container as environment variable or mounted as a file.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewMultiKeySecret(namespaceName string, secretName string, secretData map[string]string) *v1.Secret {
+	return &v1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Secret",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: namespaceName,
+		},
+		StringData: secretData,
+		Type:       "Opaque",
+	}
+}
+
 // NewDeploymentSecret creates a new Kubernetes secret object based on several parameters. This secret
 // type can be used for Kubernetes deployments from private registries.
 // Created objects only exist locally and need to be deployed to the cluster.
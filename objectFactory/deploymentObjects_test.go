@@ -0,0 +1,184 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package objectFactory
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kufast/tools"
+	"testing"
+)
+
+func TestApplyPlacementPolicy(t *testing.T) {
+	tests := []struct {
+		policy          string
+		wantSpread      bool
+		wantPodAffinity bool
+	}{
+		{"spread", true, false},
+		{"pack", false, true},
+		{"none", false, false},
+		{"", false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.policy, func(t *testing.T) {
+			podSpec := v1.PodSpec{}
+			ApplyPlacementPolicy(&podSpec, "alice", test.policy)
+
+			if got := len(podSpec.TopologySpreadConstraints) == 1; got != test.wantSpread {
+				t.Errorf("topology spread constraint = %v, want %v", got, test.wantSpread)
+			}
+			if got := podSpec.Affinity != nil && podSpec.Affinity.PodAffinity != nil; got != test.wantPodAffinity {
+				t.Errorf("pod affinity = %v, want %v", got, test.wantPodAffinity)
+			}
+			if test.wantSpread {
+				constraint := podSpec.TopologySpreadConstraints[0]
+				if constraint.TopologyKey != tools.KUFAST_NODE_HOSTNAME_LABEL || constraint.WhenUnsatisfiable != v1.ScheduleAnyway {
+					t.Errorf("topology spread constraint = %v, want a soft spread over the nodes", constraint)
+				}
+				if constraint.LabelSelector.MatchLabels[tools.KUFAST_TENANT_LABEL] != "alice" {
+					t.Errorf("label selector = %v, want the pods of tenant alice", constraint.LabelSelector)
+				}
+			}
+			if test.wantPodAffinity {
+				term := podSpec.Affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution[0].PodAffinityTerm
+				if term.TopologyKey != tools.KUFAST_NODE_HOSTNAME_LABEL || term.LabelSelector.MatchLabels[tools.KUFAST_TENANT_LABEL] != "alice" {
+					t.Errorf("pod affinity term = %v, want the pods of tenant alice on the same node", term)
+				}
+			}
+		})
+	}
+}
+
+func TestConstrainPodTemplate(t *testing.T) {
+	tests := []struct {
+		name             string
+		labels           map[string]string
+		nodeSelector     map[string]string
+		target           tools.Target
+		wantWorkload     string
+		wantNodeSelector map[string]string
+	}{
+		{"node target", nil, nil, tools.Target{Name: "node-1", AccessType: "node"}, "web",
+			map[string]string{tools.KUFAST_NODE_HOSTNAME_LABEL: "node-1"}},
+		{"group target", nil, nil, tools.Target{Name: "edge", AccessType: "group"}, "web",
+			map[string]string{tools.KUFAST_NODE_GROUP_LABEL + "edge": "true"}},
+		{"existing workload label", map[string]string{tools.KUFAST_WORKLOAD_LABEL: "frontend"}, nil,
+			tools.Target{Name: "edge", AccessType: "group"}, "frontend", map[string]string{tools.KUFAST_NODE_GROUP_LABEL + "edge": "true"}},
+		{"existing node selector", nil, map[string]string{"disktype": "ssd"}, tools.Target{Name: "node-1", AccessType: "node"}, "web",
+			map[string]string{"disktype": "ssd", tools.KUFAST_NODE_HOSTNAME_LABEL: "node-1"}},
+		{"node selector of another node", nil, map[string]string{tools.KUFAST_NODE_HOSTNAME_LABEL: "node-2"},
+			tools.Target{Name: "node-1", AccessType: "node"}, "web", map[string]string{tools.KUFAST_NODE_HOSTNAME_LABEL: "node-1"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			podMeta := metav1.ObjectMeta{Labels: test.labels}
+			podSpec := v1.PodSpec{NodeSelector: test.nodeSelector}
+			ConstrainPodTemplate(&podMeta, &podSpec, "alice-edge", "web", test.target)
+
+			if podMeta.Labels["network"] != "alice-edge" || podMeta.Labels[tools.KUFAST_TENANT_LABEL] != "alice" {
+				t.Errorf("labels = %v, want the network and tenant labels of alice-edge", podMeta.Labels)
+			}
+			if got := podMeta.Labels[tools.KUFAST_WORKLOAD_LABEL]; got != test.wantWorkload {
+				t.Errorf("workload label = %q, want %q", got, test.wantWorkload)
+			}
+			if len(podSpec.NodeSelector) != len(test.wantNodeSelector) {
+				t.Errorf("node selector = %v, want %v", podSpec.NodeSelector, test.wantNodeSelector)
+			}
+			for key, value := range test.wantNodeSelector {
+				if podSpec.NodeSelector[key] != value {
+					t.Errorf("node selector = %v, want %v", podSpec.NodeSelector, test.wantNodeSelector)
+				}
+			}
+		})
+	}
+}
+
+func TestApplySecurityLevel(t *testing.T) {
+	tests := []struct {
+		level            string
+		wantSeccomp      bool
+		wantRunAsNonRoot bool
+	}{
+		{"restricted", true, true},
+		{"baseline", true, false},
+		{"privileged", false, false},
+		{"", false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.level, func(t *testing.T) {
+			podSpec := v1.PodSpec{
+				InitContainers: []v1.Container{{Name: "init"}},
+				Containers:     []v1.Container{{Name: "web"}},
+			}
+			ApplySecurityLevel(&podSpec, test.level)
+
+			hasSeccomp := podSpec.SecurityContext != nil && podSpec.SecurityContext.SeccompProfile != nil
+			if hasSeccomp != test.wantSeccomp {
+				t.Errorf("seccomp profile set = %v, want %v", hasSeccomp, test.wantSeccomp)
+			}
+			runAsNonRoot := podSpec.SecurityContext != nil && podSpec.SecurityContext.RunAsNonRoot != nil && *podSpec.SecurityContext.RunAsNonRoot
+			if runAsNonRoot != test.wantRunAsNonRoot {
+				t.Errorf("run as non-root = %v, want %v", runAsNonRoot, test.wantRunAsNonRoot)
+			}
+			for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+				restricted := container.SecurityContext != nil && container.SecurityContext.AllowPrivilegeEscalation != nil &&
+					!*container.SecurityContext.AllowPrivilegeEscalation && container.SecurityContext.Capabilities != nil
+				if restricted != test.wantRunAsNonRoot {
+					t.Errorf("container %s restricted = %v, want %v", container.Name, restricted, test.wantRunAsNonRoot)
+				}
+			}
+		})
+	}
+}
+
+func TestApplySecurityLevelKeepsSettings(t *testing.T) {
+	runAsNonRoot := false
+	allowPrivilegeEscalation := true
+	podSpec := v1.PodSpec{
+		SecurityContext: &v1.PodSecurityContext{RunAsNonRoot: &runAsNonRoot},
+		Containers: []v1.Container{{Name: "web", SecurityContext: &v1.SecurityContext{
+			AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+		}}},
+	}
+	ApplySecurityLevel(&podSpec, "restricted")
+
+	if *podSpec.SecurityContext.RunAsNonRoot {
+		t.Errorf("run as non-root has been overwritten, want the setting of the pod kept")
+	}
+	if !*podSpec.Containers[0].SecurityContext.AllowPrivilegeEscalation {
+		t.Errorf("allow privilege escalation has been overwritten, want the setting of the container kept")
+	}
+}
+
+func TestNewProfilesConfigMap(t *testing.T) {
+	profiles := NewProfilesConfigMap("alice-edge")
+
+	if profiles.Name != tools.KUFAST_PROFILES_CONFIGMAP || profiles.Namespace != "alice-edge" {
+		t.Errorf("configmap %s/%s, want %s/%s", profiles.Namespace, profiles.Name, "alice-edge", tools.KUFAST_PROFILES_CONFIGMAP)
+	}
+	if profiles.Labels[tools.KUFAST_TENANT_LABEL] != "alice" || profiles.Labels[tools.KUFAST_PROFILES_LABEL] != "true" {
+		t.Errorf("labels = %v, want the tenant and the profiles label", profiles.Labels)
+	}
+}
@@ -0,0 +1,79 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package objectFactory
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kufast/tools"
+)
+
+// NewTargetGroupQuota creates a new Kubernetes ConfigMap object based on several parameters.
+// The ConfigMap stores the quota of a whole target-group with the same keys as the ResourceQuota of a tenant-target.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewTargetGroupQuota(groupName string, ram string, cpu string, storage string, pods string) *v1.ConfigMap {
+	newQuota := &v1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      groupName + "-groupquota",
+			Namespace: "default",
+			Labels: map[string]string{
+				tools.KUFAST_TARGET_GROUP_LABEL: groupName,
+			},
+		},
+		Data: map[string]string{},
+	}
+
+	//Set parameters only if available
+	if ram != "" {
+		qty, err := resource.ParseQuantity(ram)
+		if err == nil {
+			newQuota.Data["limits.memory"] = qty.String()
+		}
+	}
+	if cpu != "" {
+		qty, err := resource.ParseQuantity(cpu)
+		if err == nil {
+			newQuota.Data["limits.cpu"] = qty.String()
+		}
+	}
+	if storage != "" {
+		qty, err := resource.ParseQuantity(storage)
+		if err == nil {
+			newQuota.Data["limits.ephemeral-storage"] = qty.String()
+		}
+	}
+	if pods != "" {
+		qty, err := resource.ParseQuantity(pods)
+		if err == nil {
+			newQuota.Data["pods"] = qty.String()
+		}
+	}
+
+	return newQuota
+}
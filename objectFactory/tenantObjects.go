@@ -30,7 +30,10 @@ import (
 	v12 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"kufast/tools"
+	"strconv"
+	"strings"
 )
 
 // NewNamespace creates a new Kubernetes namespace object based on several parameters.
@@ -53,17 +56,44 @@ func NewNamespace(tenantName string, target tools.Target, cmd *cobra.Command) *v
 		Status: v1.NamespaceStatus{},
 	}
 
+	SetNamespaceNodeSelector(newNamespace, target)
+
+	security, _ := cmd.Flags().GetString("security")
+	SetPodSecurityLevel(newNamespace, security)
+	return newNamespace
+}
+
+// SetNamespaceNodeSelector sets the node selector annotation of a namespace, so all of its pods are scheduled on the
+// nodes of a target.
+func SetNamespaceNodeSelector(namespace *v1.Namespace, target tools.Target) {
+	if namespace.ObjectMeta.Annotations == nil {
+		namespace.ObjectMeta.Annotations = map[string]string{}
+	}
 	if target.AccessType == "node" {
-		newNamespace.ObjectMeta.Annotations["scheduler.alpha.kubernetes.io/node-selector"] = tools.KUFAST_NODE_HOSTNAME_LABEL + "=" + target.Name
+		namespace.ObjectMeta.Annotations[tools.KUFAST_NODE_SELECTOR_ANNOTATION] = tools.KUFAST_NODE_HOSTNAME_LABEL + "=" + target.Name
 	} else {
-		newNamespace.ObjectMeta.Annotations["scheduler.alpha.kubernetes.io/node-selector"] = tools.KUFAST_NODE_GROUP_LABEL + target.Name + "=true"
+		namespace.ObjectMeta.Annotations[tools.KUFAST_NODE_SELECTOR_ANNOTATION] = tools.KUFAST_NODE_GROUP_LABEL + target.Name + "=true"
+	}
+}
+
+// SetPodSecurityLevel labels a namespace for the PodSecurity admission, so pods violating the security level are
+// rejected and other workloads violating it are reported with a warning. An empty level stands for the default
+// security level of kufast, so the labels are always set.
+func SetPodSecurityLevel(namespace *v1.Namespace, level string) {
+	if level == "" {
+		level = tools.KUFAST_DEFAULT_SECURITY_LEVEL
+	}
+	if namespace.ObjectMeta.Labels == nil {
+		namespace.ObjectMeta.Labels = map[string]string{}
+	}
+	for _, mode := range []string{"enforce", "warn", "audit"} {
+		namespace.ObjectMeta.Labels[tools.KUFAST_POD_SECURITY_LABEL+mode] = level
 	}
-	return newNamespace
 }
 
 // NewLimitRange creates a new Kubernetes LimitRange object based on several parameters.
 // Created objects only exist locally and need to be deployed to the cluster.
-func NewLimitRange(namespaceName string, minStorage string, storage string) *v1.LimitRange {
+func NewLimitRange(namespaceName string, minStorage string, storage string, maxRatio string) *v1.LimitRange {
 	var newRange *v1.LimitRange
 
 	newRange = &v1.LimitRange{
@@ -98,13 +128,32 @@ func NewLimitRange(namespaceName string, minStorage string, storage string) *v1.
 	if err == nil {
 		newRange.Spec.Limits[0].Max["ephemeral-storage"] = qty
 	}
+	SetMaxLimitRequestRatio(newRange, maxRatio)
 
 	return newRange
 }
 
+// SetMaxLimitRequestRatio sets the maximum ratio between the limits and requests of CPU and memory, that a container
+// of a LimitRange may have. An empty ratio removes the restriction.
+func SetMaxLimitRequestRatio(limitRange *v1.LimitRange, maxRatio string) {
+	item := &limitRange.Spec.Limits[0]
+	if maxRatio == "" {
+		item.MaxLimitRequestRatio = nil
+		return
+	}
+
+	qty, err := resource.ParseQuantity(maxRatio)
+	if err == nil {
+		item.MaxLimitRequestRatio = v1.ResourceList{
+			"cpu":    qty,
+			"memory": qty,
+		}
+	}
+}
+
 // NewResourceQuota creates a new Kubernetes ResourceQouta object based on several parameters.
 // Created objects only exist locally and need to be deployed to the cluster.
-func NewResourceQuota(namespace string, ram string, cpu string, storage string, pods string) *v1.ResourceQuota {
+func NewResourceQuota(namespace string, ram string, cpu string, storage string, pods string, services string, nodePorts string, configMaps string, volumes string) *v1.ResourceQuota {
 	var newQuota *v1.ResourceQuota
 	newQuota = &v1.ResourceQuota{
 		TypeMeta: metav1.TypeMeta{
@@ -144,6 +193,36 @@ func NewResourceQuota(namespace string, ram string, cpu string, storage string,
 		}
 	}
 
+	if services != "" {
+		qty, err := resource.ParseQuantity(services)
+		if err == nil {
+			newQuota.Spec.Hard["services"] = qty
+		}
+	}
+
+	if nodePorts != "" {
+		qty, err := resource.ParseQuantity(nodePorts)
+		if err == nil {
+			newQuota.Spec.Hard["services.nodeports"] = qty
+		}
+	}
+	//LoadBalancers would expose the pods of a tenant without the control of kufast
+	newQuota.Spec.Hard["services.loadbalancers"] = resource.MustParse("0")
+
+	if configMaps != "" {
+		qty, err := resource.ParseQuantity(configMaps)
+		if err == nil {
+			newQuota.Spec.Hard["configmaps"] = qty
+		}
+	}
+
+	if volumes != "" {
+		qty, err := resource.ParseQuantity(volumes)
+		if err == nil {
+			newQuota.Spec.Hard["persistentvolumeclaims"] = qty
+		}
+	}
+
 	if storage != "" {
 		qty, err := resource.ParseQuantity(storage)
 		if err == nil {
@@ -155,6 +234,80 @@ func NewResourceQuota(namespace string, ram string, cpu string, storage string,
 	return newQuota
 }
 
+// SetResourceQuotaRequests sets the total requests of CPU and memory of a ResourceQuota separately from its limits.
+// Only non-empty values are set.
+func SetResourceQuotaRequests(quota *v1.ResourceQuota, ram string, cpu string) {
+	if ram != "" {
+		qty, err := resource.ParseQuantity(ram)
+		if err == nil {
+			quota.Spec.Hard["requests.memory"] = qty
+		}
+	}
+	if cpu != "" {
+		qty, err := resource.ParseQuantity(cpu)
+		if err == nil {
+			quota.Spec.Hard["requests.cpu"] = qty
+		}
+	}
+}
+
+// UpdateResourceQuota changes the limits of an existing ResourceQuota. Only non-empty values are changed. Requests are
+// only changed with the separate request values, so a request quota different from the limits is kept.
+func UpdateResourceQuota(quota *v1.ResourceQuota, ram string, cpu string, ramRequest string, cpuRequest string,
+	storage string, services string, nodePorts string, configMaps string, volumes string) {
+	if quota.Spec.Hard == nil {
+		quota.Spec.Hard = v1.ResourceList{}
+	}
+	if ram != "" {
+		qty, err := resource.ParseQuantity(ram)
+		if err == nil {
+			quota.Spec.Hard["limits.memory"] = qty
+		}
+	}
+	if cpu != "" {
+		qty, err := resource.ParseQuantity(cpu)
+		if err == nil {
+			quota.Spec.Hard["limits.cpu"] = qty
+		}
+	}
+	SetResourceQuotaRequests(quota, ramRequest, cpuRequest)
+
+	if storage != "" {
+		qty, err := resource.ParseQuantity(storage)
+		if err == nil {
+			quota.Spec.Hard["limits.ephemeral-storage"] = qty
+			quota.Spec.Hard["requests.ephemeral-storage"] = qty
+			quota.Spec.Hard["requests.storage"] = qty
+		}
+	}
+
+	if services != "" {
+		qty, err := resource.ParseQuantity(services)
+		if err == nil {
+			quota.Spec.Hard["services"] = qty
+		}
+	}
+	if nodePorts != "" {
+		qty, err := resource.ParseQuantity(nodePorts)
+		if err == nil {
+			quota.Spec.Hard["services.nodeports"] = qty
+		}
+	}
+	quota.Spec.Hard["services.loadbalancers"] = resource.MustParse("0")
+	if configMaps != "" {
+		qty, err := resource.ParseQuantity(configMaps)
+		if err == nil {
+			quota.Spec.Hard["configmaps"] = qty
+		}
+	}
+	if volumes != "" {
+		qty, err := resource.ParseQuantity(volumes)
+		if err == nil {
+			quota.Spec.Hard["persistentvolumeclaims"] = qty
+		}
+	}
+}
+
 // NewTenantUser creates a new Kubernetes ServiceAccount object based on several parameters.
 // This is the basis user for a kufast tenant
 // Created objects only exist locally and need to be deployed to the cluster.
@@ -193,7 +346,27 @@ func NewRole(namespaceName string) *v12.Role {
 			{
 				APIGroups: []string{""},
 				Verbs:     []string{"get", "list", "watch", "update", "delete", "create"},
-				Resources: []string{"pods", "secrets", "pods/exec", "events", "pods/log"},
+				Resources: []string{"pods", "secrets", "pods/exec", "events", "pods/log", "services", "configmaps", "persistentvolumeclaims"},
+			},
+			{
+				APIGroups: []string{""},
+				Verbs:     []string{"get", "list", "watch"},
+				Resources: []string{"resourcequotas", "limitranges"},
+			},
+			{
+				APIGroups: []string{"apps"},
+				Verbs:     []string{"get", "list", "watch", "update", "delete", "create"},
+				Resources: []string{"deployments", "replicasets"},
+			},
+			{
+				APIGroups: []string{"batch"},
+				Verbs:     []string{"get", "list", "watch", "update", "delete", "create"},
+				Resources: []string{"jobs", "cronjobs"},
+			},
+			{
+				APIGroups: []string{"networking.k8s.io"},
+				Verbs:     []string{"get", "list", "watch", "update", "delete", "create"},
+				Resources: []string{"ingresses"},
 			},
 		},
 	}
@@ -251,6 +424,53 @@ func NewNetworkPolicy(namespaceName string, tenant string) *n1.NetworkPolicy {
 
 }
 
+// NewExposeNetworkPolicy creates a new Kubernetes NetworkPolicy object based on several parameters.
+// This network policy allows traffic from outside of the cluster to pods of a tenant-target that have been exposed
+// through a NodePort. Traffic from the pod networks of the nodes is excluded, so the pods of other tenants cannot use
+// the policy to bypass the isolation created with NewNetworkPolicy.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewExposeNetworkPolicy(namespaceName string, podCIDRs []string) *n1.NetworkPolicy {
+	ipv4 := &n1.IPBlock{CIDR: "0.0.0.0/0"}
+	ipv6 := &n1.IPBlock{CIDR: "::/0"}
+	for _, podCIDR := range podCIDRs {
+		if strings.Contains(podCIDR, ":") {
+			ipv6.Except = append(ipv6.Except, podCIDR)
+		} else {
+			ipv4.Except = append(ipv4.Except, podCIDR)
+		}
+	}
+
+	return &n1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      namespaceName + "-expose-networkpolicy",
+			Namespace: namespaceName,
+		},
+		Spec: n1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					tools.KUFAST_EXPOSED_LABEL: "true",
+				},
+			},
+			Ingress: []n1.NetworkPolicyIngressRule{
+				{
+					From: []n1.NetworkPolicyPeer{
+						{IPBlock: ipv4},
+						{IPBlock: ipv6},
+					},
+				},
+			},
+			PolicyTypes: []n1.PolicyType{
+				"Ingress",
+			},
+		},
+	}
+
+}
+
 // NewTenantRolebinding creates a new Kubernetes RoleBinding object based on several parameters.
 // This Role binding is preconfigured for the role binding of a tenant target role to a tenant.
 // Created objects only exist locally and need to be deployed to the cluster.
@@ -297,7 +517,7 @@ func NewTenantDefaultRole(tenantName string) *v12.Role {
 		},
 		Rules: []v12.PolicyRule{
 			{
-				Verbs:         []string{"get"},
+				Verbs:         []string{"get", "watch"},
 				APIGroups:     []string{""},
 				Resources:     []string{"serviceaccounts"},
 				ResourceNames: []string{tenantName + "-user"},
@@ -337,3 +557,131 @@ func NewTenantDefaultRoleBinding(tenantName string) *v12.RoleBinding {
 	}
 
 }
+
+// NewTenantDomainPolicy creates a new Kubernetes ValidatingAdmissionPolicy object based on several parameters.
+// The policy only admits ingresses, whose hostnames are within the domains allowed for the tenant, so tenants cannot
+// route requests for foreign hostnames with their own credentials. The policy is created as unstructured object, as
+// the admissionregistration.k8s.io/v1 API is not part of the client library yet.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewTenantDomainPolicy(tenantName string, domains []string) *unstructured.Unstructured {
+	allowedHost := func(host string) string {
+		return "variables.domains.exists(domain, " + host + " == domain || " + host + ".endsWith('.' + domain))"
+	}
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "admissionregistration.k8s.io/v1",
+			"kind":       "ValidatingAdmissionPolicy",
+			"metadata": map[string]interface{}{
+				"name": tenantName + "-domains",
+				"labels": map[string]interface{}{
+					tools.KUFAST_TENANT_LABEL: tenantName,
+				},
+			},
+			"spec": map[string]interface{}{
+				"failurePolicy": "Fail",
+				"matchConstraints": map[string]interface{}{
+					"resourceRules": []interface{}{
+						map[string]interface{}{
+							"apiGroups":   []interface{}{"networking.k8s.io"},
+							"apiVersions": []interface{}{"*"},
+							"operations":  []interface{}{"CREATE", "UPDATE"},
+							"resources":   []interface{}{"ingresses"},
+						},
+					},
+				},
+				"variables": []interface{}{
+					map[string]interface{}{
+						"name":       "domains",
+						"expression": newCelStringList(domains),
+					},
+				},
+				"validations": []interface{}{
+					map[string]interface{}{
+						"expression": "!has(object.spec.defaultBackend)",
+						"message":    "Ingresses of tenants must not have a default backend.",
+					},
+					map[string]interface{}{
+						"expression": "!has(object.spec.rules) || object.spec.rules.all(rule, has(rule.host) && " +
+							allowedHost("rule.host") + ")",
+						"message": "The hostnames of the ingress must be within the domains allowed for the tenant.",
+					},
+					map[string]interface{}{
+						"expression": "!has(object.spec.tls) || object.spec.tls.all(tls, !has(tls.hosts) || tls.hosts.all(host, " +
+							allowedHost("host") + "))",
+						"message": "The TLS hostnames of the ingress must be within the domains allowed for the tenant.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// NewTenantDomainPolicyBinding creates a new Kubernetes ValidatingAdmissionPolicyBinding object based on several
+// parameters. The binding applies the domain policy of a tenant to all of its tenant-targets.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewTenantDomainPolicyBinding(tenantName string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "admissionregistration.k8s.io/v1",
+			"kind":       "ValidatingAdmissionPolicyBinding",
+			"metadata": map[string]interface{}{
+				"name": tenantName + "-domains",
+				"labels": map[string]interface{}{
+					tools.KUFAST_TENANT_LABEL: tenantName,
+				},
+			},
+			"spec": map[string]interface{}{
+				"policyName":        tenantName + "-domains",
+				"validationActions": []interface{}{"Deny"},
+				"matchResources": map[string]interface{}{
+					"namespaceSelector": map[string]interface{}{
+						"matchLabels": map[string]interface{}{
+							tools.KUFAST_TENANT_LABEL: tenantName,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// newCelStringList returns a CEL list literal of the domains, normalized like in tools.IsAllowedDomain.
+func newCelStringList(domains []string) string {
+	literals := []string{}
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.Trim(domain, "."))
+		if domain != "" {
+			literals = append(literals, strconv.Quote(domain))
+		}
+	}
+	return "[" + strings.Join(literals, ", ") + "]"
+}
+
+// NewTenantTargetEvent creates a new Kubernetes Event object based on several parameters.
+// The event is attached to the tenant-target itself to notify its tenant about cluster operations.
+// Created objects only exist locally and need to be deployed to the cluster.
+func NewTenantTargetEvent(namespaceName string, reason string, message string) *v1.Event {
+	now := metav1.Now()
+	return &v1.Event{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Event",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: namespaceName + "-kufast-",
+			Namespace:    namespaceName,
+		},
+		InvolvedObject: v1.ObjectReference{
+			Kind:       "Namespace",
+			APIVersion: "v1",
+			Name:       namespaceName,
+		},
+		Reason:         reason,
+		Message:        message,
+		Type:           v1.EventTypeWarning,
+		Source:         v1.EventSource{Component: "kufast"},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+}
@@ -0,0 +1,276 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package objectFactory
+
+import (
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"kufast/tools"
+	"strings"
+	"testing"
+)
+
+func TestNewResourceQuota(t *testing.T) {
+	quota := NewResourceQuota("alice-edge", "2Gi", "500m", "10Gi", "", "", "", "", "")
+
+	want := map[v1.ResourceName]string{
+		"limits.memory":              "2Gi",
+		"requests.memory":            "2Gi",
+		"limits.cpu":                 "500m",
+		"requests.cpu":               "500m",
+		"requests.storage":           "10Gi",
+		"requests.ephemeral-storage": "10Gi",
+		"limits.ephemeral-storage":   "10Gi",
+	}
+	for name, value := range want {
+		qty, ok := quota.Spec.Hard[name]
+		if !ok || qty.Cmp(resource.MustParse(value)) != 0 {
+			t.Errorf("quota %s = %v, want %s", name, qty.String(), value)
+		}
+	}
+	if _, ok := quota.Spec.Hard["pods"]; ok {
+		t.Errorf("quota pods is set, want no limit for an empty value")
+	}
+}
+
+func TestSetResourceQuotaRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		ram     string
+		cpu     string
+		wantRam string
+		wantCpu string
+	}{
+		{"requests kept", "", "", "2Gi", "1"},
+		{"separate memory request", "1Gi", "", "1Gi", "1"},
+		{"separate cpu request", "", "250m", "2Gi", "250m"},
+		{"invalid request ignored", "lots", "250m", "2Gi", "250m"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			quota := NewResourceQuota("alice-edge", "2Gi", "1", "", "", "", "", "", "")
+			SetResourceQuotaRequests(quota, test.ram, test.cpu)
+
+			if got := quota.Spec.Hard["requests.memory"]; got.Cmp(resource.MustParse(test.wantRam)) != 0 {
+				t.Errorf("requests.memory = %v, want %s", got.String(), test.wantRam)
+			}
+			if got := quota.Spec.Hard["requests.cpu"]; got.Cmp(resource.MustParse(test.wantCpu)) != 0 {
+				t.Errorf("requests.cpu = %v, want %s", got.String(), test.wantCpu)
+			}
+			if got := quota.Spec.Hard["limits.memory"]; got.Cmp(resource.MustParse("2Gi")) != 0 {
+				t.Errorf("limits.memory = %v, want the limit untouched", got.String())
+			}
+		})
+	}
+}
+
+func TestUpdateResourceQuota(t *testing.T) {
+	tests := []struct {
+		name       string
+		ram        string
+		cpu        string
+		ramRequest string
+		cpuRequest string
+		want       map[v1.ResourceName]string
+	}{
+		{"nothing changed", "", "", "", "",
+			map[v1.ResourceName]string{"limits.memory": "2Gi", "requests.memory": "1Gi", "limits.cpu": "1", "requests.cpu": "500m"}},
+		{"limits raised", "4Gi", "2", "", "",
+			map[v1.ResourceName]string{"limits.memory": "4Gi", "requests.memory": "1Gi", "limits.cpu": "2", "requests.cpu": "500m"}},
+		{"requests raised", "", "", "2Gi", "1",
+			map[v1.ResourceName]string{"limits.memory": "2Gi", "requests.memory": "2Gi", "limits.cpu": "1", "requests.cpu": "1"}},
+		{"invalid limit ignored", "lots", "", "", "",
+			map[v1.ResourceName]string{"limits.memory": "2Gi", "requests.memory": "1Gi", "limits.cpu": "1", "requests.cpu": "500m"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			quota := NewResourceQuota("alice-edge", "2Gi", "1", "", "", "", "", "", "")
+			SetResourceQuotaRequests(quota, "1Gi", "500m")
+			UpdateResourceQuota(quota, test.ram, test.cpu, test.ramRequest, test.cpuRequest, "", "", "", "", "")
+
+			for name, value := range test.want {
+				if got := quota.Spec.Hard[name]; got.Cmp(resource.MustParse(value)) != 0 {
+					t.Errorf("quota %s = %v, want %s", name, got.String(), value)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateResourceQuotaCounts(t *testing.T) {
+	quota := NewResourceQuota("alice-edge", "", "", "", "", "10", "2", "", "")
+	UpdateResourceQuota(quota, "", "", "", "", "5Gi", "", "0", "20", "3")
+
+	want := map[v1.ResourceName]string{
+		"services":                   "10",
+		"services.nodeports":         "0",
+		"services.loadbalancers":     "0",
+		"configmaps":                 "20",
+		"persistentvolumeclaims":     "3",
+		"requests.storage":           "5Gi",
+		"requests.ephemeral-storage": "5Gi",
+		"limits.ephemeral-storage":   "5Gi",
+	}
+	for name, value := range want {
+		if got, ok := quota.Spec.Hard[name]; !ok || got.Cmp(resource.MustParse(value)) != 0 {
+			t.Errorf("quota %s = %v, want %s", name, got.String(), value)
+		}
+	}
+}
+
+func TestSetMaxLimitRequestRatio(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxRatio string
+		want     string
+	}{
+		{"ratio set", "2", "2"},
+		{"ratio removed", "", ""},
+		{"invalid ratio ignored", "double", "3"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			limitRange := NewLimitRange("alice-edge", "", "", "3")
+			SetMaxLimitRequestRatio(limitRange, test.maxRatio)
+
+			ratios := limitRange.Spec.Limits[0].MaxLimitRequestRatio
+			if test.want == "" {
+				if ratios != nil {
+					t.Errorf("max limit request ratio = %v, want none", ratios)
+				}
+				return
+			}
+			for _, name := range []v1.ResourceName{"cpu", "memory"} {
+				if got := ratios[name]; got.Cmp(resource.MustParse(test.want)) != 0 {
+					t.Errorf("max limit request ratio of %s = %v, want %s", name, got.String(), test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewExposeNetworkPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		podCIDRs   []string
+		wantExcept map[string][]string
+	}{
+		{"no pod networks", nil, map[string][]string{"0.0.0.0/0": nil, "::/0": nil}},
+		{"ipv4 pod networks", []string{"10.244.0.0/24", "10.244.1.0/24"},
+			map[string][]string{"0.0.0.0/0": {"10.244.0.0/24", "10.244.1.0/24"}, "::/0": nil}},
+		{"dual-stack pod networks", []string{"10.244.0.0/24", "fd00:10:244::/64"},
+			map[string][]string{"0.0.0.0/0": {"10.244.0.0/24"}, "::/0": {"fd00:10:244::/64"}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := NewExposeNetworkPolicy("alice-edge", test.podCIDRs)
+
+			selector := policy.Spec.PodSelector.MatchLabels
+			if len(selector) != 1 || selector[tools.KUFAST_EXPOSED_LABEL] != "true" {
+				t.Errorf("pod selector = %v, want only exposed pods", selector)
+			}
+			if len(policy.Spec.Ingress) != 1 || len(policy.Spec.Ingress[0].From) != len(test.wantExcept) {
+				t.Fatalf("ingress rules = %v, want a single rule with %d ip blocks", policy.Spec.Ingress, len(test.wantExcept))
+			}
+			for _, peer := range policy.Spec.Ingress[0].From {
+				if peer.IPBlock == nil || peer.PodSelector != nil || peer.NamespaceSelector != nil {
+					t.Errorf("peer = %v, want an ip block only", peer)
+					continue
+				}
+				wantExcept, ok := test.wantExcept[peer.IPBlock.CIDR]
+				if !ok {
+					t.Errorf("ip block %s, want one of %v", peer.IPBlock.CIDR, test.wantExcept)
+					continue
+				}
+				if strings.Join(peer.IPBlock.Except, ",") != strings.Join(wantExcept, ",") {
+					t.Errorf("exceptions of %s = %v, want %v", peer.IPBlock.CIDR, peer.IPBlock.Except, wantExcept)
+				}
+			}
+		})
+	}
+}
+
+func TestSetPodSecurityLevel(t *testing.T) {
+	tests := []struct {
+		level string
+		want  string
+	}{
+		{"baseline", "baseline"},
+		{"privileged", "privileged"},
+		{"", tools.KUFAST_DEFAULT_SECURITY_LEVEL},
+	}
+	for _, test := range tests {
+		t.Run(test.level, func(t *testing.T) {
+			namespace := &v1.Namespace{}
+			SetPodSecurityLevel(namespace, test.level)
+
+			for _, mode := range []string{"enforce", "warn", "audit"} {
+				if got := namespace.Labels[tools.KUFAST_POD_SECURITY_LABEL+mode]; got != test.want {
+					t.Errorf("label %s = %q, want %q", tools.KUFAST_POD_SECURITY_LABEL+mode, got, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewTenantDomainPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		domains []string
+		want    string
+	}{
+		{"no domains", []string{}, `[]`},
+		{"normalized domains", []string{"Example.com.", "", ".apps.acme.io"}, `["example.com", "apps.acme.io"]`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := NewTenantDomainPolicy("alice", test.domains)
+
+			variables, _, _ := unstructured.NestedSlice(policy.Object, "spec", "variables")
+			if len(variables) != 1 || variables[0].(map[string]interface{})["expression"] != test.want {
+				t.Errorf("variables = %v, want domains %s", variables, test.want)
+			}
+			validations, _, _ := unstructured.NestedSlice(policy.Object, "spec", "validations")
+			for _, validation := range validations {
+				expression := validation.(map[string]interface{})["expression"].(string)
+				if strings.Count(expression, "(") != strings.Count(expression, ")") {
+					t.Errorf("unbalanced expression %s", expression)
+				}
+			}
+		})
+	}
+}
+
+func TestNewTenantDomainPolicyBinding(t *testing.T) {
+	binding := NewTenantDomainPolicyBinding("alice")
+
+	if name, _, _ := unstructured.NestedString(binding.Object, "spec", "policyName"); name != NewTenantDomainPolicy("alice", nil).GetName() {
+		t.Errorf("policy name = %q, want the domain policy of the tenant", name)
+	}
+	selector, _, _ := unstructured.NestedStringMap(binding.Object, "spec", "matchResources", "namespaceSelector", "matchLabels")
+	if len(selector) != 1 || selector[tools.KUFAST_TENANT_LABEL] != "alice" {
+		t.Errorf("namespace selector = %v, want the tenant-targets of the tenant", selector)
+	}
+}
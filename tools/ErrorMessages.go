@@ -23,7 +23,10 @@ SOFTWARE.
 */
 package tools
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 // ERROR_WRONG_NUMBER_ARGUMENTS returns the error message if the wrong number of arguments have been provided
 const ERROR_WRONG_NUMBER_ARGUMENTS = "Error: You did not provide a valid amount of arguments."
@@ -33,3 +36,77 @@ const ERROR_WRONG_NUMBER_ARGUMENTS = "Error: You did not provide a valid amount
 func CreateAlphaNumericError(objectName string) error {
 	return errors.New(objectName + ": Name has to be alphanumeric.")
 }
+
+// CreateInvalidPlacementError returns an error object with the hint that the placement policy passed by a string is
+// not supported.
+func CreateInvalidPlacementError(policy string) error {
+	return errors.New(policy + ": Placement policy has to be one of none, spread or pack.")
+}
+
+// CreateInvalidSecurityLevelError returns an error object with the hint that the pod security level passed by a string
+// is not supported.
+func CreateInvalidSecurityLevelError(level string) error {
+	return errors.New(level + ": Security level has to be one of privileged, baseline or restricted.")
+}
+
+// CreatePodSecurityError returns an error object with the hint that a workload needs more permissions than the security
+// level of its tenant-target allows. The message of the admission error is kept, as it lists the offending settings.
+func CreatePodSecurityError(err error) error {
+	return errors.New(err.Error() + "\nThe workload requires more permissions than the security level of its tenant-target allows. " +
+		"Please remove the settings listed above or ask your administrator for a lower security level.")
+}
+
+// CreateQuotaExceededError returns an error object with the hint that a workload exceeds the quota of its tenant-target.
+// The message of the admission error is kept, as it lists the requested, used and limited resources.
+func CreateQuotaExceededError(err error) error {
+	return errors.New(err.Error() + "\nThe quota of the tenant-target is exceeded. Please lower the limits of the workload or " +
+		"delete other workloads. 'kufast get tenant-target' shows the current usage.")
+}
+
+// CreateImageRunsAsRootError returns an error object with the hint that the image of a container wants to run as root,
+// which is not allowed by the restricted security level.
+func CreateImageRunsAsRootError(containerName string) error {
+	return errors.New(containerName + ": The image runs as root, which the restricted security level of the tenant-target does not allow. " +
+		"Please use --run-as-user with a non-root user id, an image that runs as non-root user or ask your administrator for the baseline security level.")
+}
+
+// CreatePodHasOwnerError returns an error object with the hint that a pod is managed by a controller and has to be
+// updated through its workload.
+func CreatePodHasOwnerError(podName string, ownerKind string, ownerName string) error {
+	if ownerKind == "ReplicaSet" {
+		return errors.New(podName + ": The pod belongs to a deployment (replica set " + ownerName + "). " +
+			"Please update the deployment with 'kufast update deployment' instead.")
+	}
+	return errors.New(podName + ": The pod belongs to " + ownerKind + " " + ownerName + " and cannot be updated on its own. " +
+		"Please update or recreate the " + ownerKind + " instead.")
+}
+
+// ERROR_INVALID_REPLICAS returns the error message if a negative number of replicas has been provided
+const ERROR_INVALID_REPLICAS = "Error: The number of replicas must not be negative."
+
+// ERROR_INVALID_JOB_SETTINGS returns the error message if the completions, parallelism or backoff limit of a job are invalid
+const ERROR_INVALID_JOB_SETTINGS = "Error: Completions and parallelism must be at least 1, the backoff limit must not be negative."
+
+// ERROR_MISSING_SCHEDULE returns the error message if a cronjob has been created without a schedule
+const ERROR_MISSING_SCHEDULE = "Error: You need to provide a schedule in cron format, e.g. \"0 2 * * *\"."
+
+// ERROR_NO_PORTS returns the error message if a workload should be exposed without any port
+const ERROR_NO_PORTS = "Error: Neither the workload nor the command line provide a port to expose. Please use --port."
+
+// CreateDomainNotAllowedError returns an error object with the hint that the hostname passed by a string is not within
+// the domains allowed for the tenant.
+func CreateDomainNotAllowedError(hostname string, allowedDomains []string) error {
+	if len(allowedDomains) == 0 {
+		return errors.New(hostname + ": No domains have been allowed for your tenant. Please contact your administrator.")
+	}
+	return errors.New(hostname + ": Hostname has to be within one of the allowed domains " + strings.Join(allowedDomains, ", ") + ".")
+}
+
+// ERROR_NO_DATA returns the error message if an object should be created without any data
+const ERROR_NO_DATA = "Error: You did not provide any data. Please use --from-literal or --from-file."
+
+// ERROR_PROBE_TIMING_WITHOUT_PROBE returns the error message if the timing of probes is set without any probe
+const ERROR_PROBE_TIMING_WITHOUT_PROBE = "Error: --probe-delay, --probe-period and --probe-failures only apply together with --liveness, --readiness or --startup."
+
+// ERROR_INVALID_LIMIT_REQUEST_RATIO returns the error message if the maximum ratio between limits and requests is invalid
+const ERROR_INVALID_LIMIT_REQUEST_RATIO = "Error: The maximum ratio between limits and requests has to be a number of at least 1, e.g. 2."
@@ -41,3 +41,9 @@ const MESSAGE_DONE = "Complete!"
 // MESSAGE_INTERACTIVE_IGNORE_INPUT
 const MESSAGE_INTERACTIVE_IGNORE_INPUT = `Please note: Interactive mode will ignore all arguments, you entered, 
 but will retain flag values`
+
+// CreateHistoryWarning returns the warning displayed, if the revision history of a pod could not be recorded
+func CreateHistoryWarning(podName string, err error) string {
+	return "Warning: The revision history of pod " + podName + " could not be recorded: " + err.Error() +
+		"\nThe pod cannot be rolled back to this revision."
+}
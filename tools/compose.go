@@ -0,0 +1,352 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sigs.k8s.io/yaml"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ComposeFile represents the parts of a docker-compose file kufast is able to import.
+type ComposeFile struct {
+	Services map[string]ComposeService `json:"services"`
+	Secrets  map[string]ComposeSecret  `json:"secrets,omitempty"`
+}
+
+// ComposeService represents a single service of a docker-compose file.
+type ComposeService struct {
+	Image          string             `json:"image,omitempty"`
+	ContainerName  string             `json:"container_name,omitempty"`
+	Command        ComposeStringList  `json:"command,omitempty"`
+	Entrypoint     ComposeStringList  `json:"entrypoint,omitempty"`
+	Environment    ComposeEnvironment `json:"environment,omitempty"`
+	EnvFile        ComposeStringList  `json:"env_file,omitempty"`
+	Ports          []json.RawMessage  `json:"ports,omitempty"`
+	Expose         []ComposeScalar    `json:"expose,omitempty"`
+	Restart        string             `json:"restart,omitempty"`
+	WorkingDir     string             `json:"working_dir,omitempty"`
+	User           string             `json:"user,omitempty"`
+	Cpus           ComposeScalar      `json:"cpus,omitempty"`
+	MemLimit       ComposeScalar      `json:"mem_limit,omitempty"`
+	MemReservation ComposeScalar      `json:"mem_reservation,omitempty"`
+	Secrets        []json.RawMessage  `json:"secrets,omitempty"`
+	Deploy         ComposeDeploy      `json:"deploy,omitempty"`
+}
+
+// ComposeDeploy represents the deploy section of a service of a docker-compose file.
+type ComposeDeploy struct {
+	Replicas  *int32 `json:"replicas,omitempty"`
+	Resources struct {
+		Limits       ComposeResources `json:"limits,omitempty"`
+		Reservations ComposeResources `json:"reservations,omitempty"`
+	} `json:"resources,omitempty"`
+}
+
+// ComposeResources represents the resource limits or reservations of a service of a docker-compose file.
+type ComposeResources struct {
+	Cpus   ComposeScalar `json:"cpus,omitempty"`
+	Memory ComposeScalar `json:"memory,omitempty"`
+}
+
+// ComposeSecret represents a top level secret of a docker-compose file, read either from a file or from an
+// environment variable.
+type ComposeSecret struct {
+	File        string `json:"file,omitempty"`
+	Environment string `json:"environment,omitempty"`
+}
+
+// ComposeScalar is a value of a docker-compose file that may be written as string or as number.
+type ComposeScalar string
+
+// UnmarshalJSON reads a string or a number as string.
+func (s *ComposeScalar) UnmarshalJSON(data []byte) error {
+	var value string
+	if json.Unmarshal(data, &value) == nil {
+		*s = ComposeScalar(value)
+		return nil
+	}
+	var number json.Number
+	err := json.Unmarshal(data, &number)
+	if err != nil {
+		return err
+	}
+	*s = ComposeScalar(number.String())
+	return nil
+}
+
+// ComposeStringList is a value of a docker-compose file that may be written as single string or as list of strings.
+// A single string is split at whitespaces like in a shell, words may be quoted.
+type ComposeStringList []string
+
+// UnmarshalJSON reads a string or a list of strings as list.
+func (l *ComposeStringList) UnmarshalJSON(data []byte) error {
+	var value string
+	if json.Unmarshal(data, &value) == nil {
+		*l = splitShellWords(value)
+		return nil
+	}
+	var values []string
+	err := json.Unmarshal(data, &values)
+	if err != nil {
+		return err
+	}
+	*l = values
+	return nil
+}
+
+// splitShellWords splits a command at whitespaces. Whitespaces within single or double quotes are kept.
+func splitShellWords(command string) []string {
+	var words []string
+	var word strings.Builder
+	var quote rune
+	inWord := false
+	for _, char := range command {
+		switch {
+		case quote != 0 && char == quote:
+			quote = 0
+		case quote != 0:
+			word.WriteRune(char)
+		case char == '"' || char == '\'':
+			quote = char
+			inWord = true
+		case char == ' ' || char == '\t' || char == '\n':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(char)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words
+}
+
+// ComposeEnvironment holds the environment variables of a service of a docker-compose file, which may be written as
+// map or as list of KEY=VALUE entries. Variables without a value are taken from the environment of the user.
+type ComposeEnvironment map[string]string
+
+// UnmarshalJSON reads a map or a list of KEY=VALUE entries as map.
+func (e *ComposeEnvironment) UnmarshalJSON(data []byte) error {
+	result := ComposeEnvironment{}
+
+	var values map[string]*ComposeScalar
+	if json.Unmarshal(data, &values) == nil {
+		for key, value := range values {
+			if value == nil {
+				result[key] = os.Getenv(key)
+			} else {
+				result[key] = string(*value)
+			}
+		}
+		*e = result
+		return nil
+	}
+
+	var entries []string
+	err := json.Unmarshal(data, &entries)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		key, value, found := strings.Cut(entry, "=")
+		if !found {
+			value = os.Getenv(key)
+		}
+		result[key] = value
+	}
+	*e = result
+	return nil
+}
+
+// composeKeys lists the keys of a docker-compose file kufast is able to import. All other keys are reported as
+// unsupported.
+var composeKeys = map[string][]string{
+	"":        {"version", "name", "services", "secrets"},
+	"service": {"image", "command", "entrypoint", "environment", "env_file", "ports", "expose", "restart", "working_dir", "user", "cpus", "mem_limit", "mem_reservation", "secrets", "deploy", "container_name"},
+	"deploy":  {"replicas", "resources"},
+}
+
+// ReadComposeFile reads a docker-compose file. Variables like ${VAR} or ${VAR:-default} are replaced with the values of
+// the .env file next to the compose file or the environment of the user. Besides the parsed file, the keys of the file
+// kufast is not able to import are returned.
+func ReadComposeFile(fileName string) (*ComposeFile, []string, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variables := map[string]string{}
+	dotEnv, err := ReadEnvFile(filepath.Join(filepath.Dir(fileName), ".env"))
+	if err == nil {
+		for _, variable := range dotEnv {
+			key, value, _ := strings.Cut(variable, "=")
+			variables[key] = value
+		}
+	}
+	content = []byte(expandComposeVariables(string(content), variables))
+
+	var composeFile ComposeFile
+	err = yaml.Unmarshal(content, &composeFile)
+	if err != nil {
+		return nil, nil, errors.New(fileName + ": " + err.Error())
+	}
+	if len(composeFile.Services) == 0 {
+		return nil, nil, errors.New(fileName + ": The compose file does not contain any services.")
+	}
+
+	//Find the keys kufast does not support
+	var raw map[string]interface{}
+	err = yaml.Unmarshal(content, &raw)
+	if err != nil {
+		return nil, nil, errors.New(fileName + ": " + err.Error())
+	}
+	unsupported := unsupportedComposeKeys("", raw, "")
+	services, _ := raw["services"].(map[string]interface{})
+	for serviceName, value := range services {
+		service, _ := value.(map[string]interface{})
+		unsupported = append(unsupported, unsupportedComposeKeys("service", service, "services."+serviceName+".")...)
+		if deploy, ok := service["deploy"].(map[string]interface{}); ok {
+			unsupported = append(unsupported, unsupportedComposeKeys("deploy", deploy, "services."+serviceName+".deploy.")...)
+		}
+	}
+	sort.Strings(unsupported)
+
+	return &composeFile, unsupported, nil
+}
+
+// expandComposeVariables replaces variables like $VAR, ${VAR} or ${VAR:-default} in the content of a compose file.
+// Values are taken from the variables passed or, if not found there, from the environment of the user. $$ escapes a
+// literal dollar sign.
+func expandComposeVariables(content string, variables map[string]string) string {
+	return os.Expand(content, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		name, defaultValue, hasDefault := strings.Cut(strings.Replace(name, ":-", "-", 1), "-")
+		value, ok := variables[name]
+		if !ok {
+			value, ok = os.LookupEnv(name)
+		}
+		if (!ok || value == "") && hasDefault {
+			return defaultValue
+		}
+		return value
+	})
+}
+
+// unsupportedComposeKeys returns all keys of a section of a docker-compose file, which are not listed in composeKeys.
+func unsupportedComposeKeys(section string, values map[string]interface{}, prefix string) []string {
+	var unsupported []string
+	for key := range values {
+		supported := false
+		for _, supportedKey := range composeKeys[section] {
+			if key == supportedKey {
+				supported = true
+			}
+		}
+		if !supported {
+			unsupported = append(unsupported, prefix+key)
+		}
+	}
+	return unsupported
+}
+
+// ParseComposePort returns the container port and the published port of a port entry of a docker-compose file.
+// Entries can be numbers, strings in the format [ip:][published:]target[/protocol] or objects with a target. The
+// published port is empty, if the entry does not publish the port on the host.
+func ParseComposePort(entry json.RawMessage) (int32, string, error) {
+	var long struct {
+		Target    *int32        `json:"target"`
+		Published ComposeScalar `json:"published"`
+	}
+	if json.Unmarshal(entry, &long) == nil && long.Target != nil {
+		return *long.Target, string(long.Published), nil
+	}
+
+	var short ComposeScalar
+	err := json.Unmarshal(entry, &short)
+	if err != nil {
+		return 0, "", errors.New(string(entry) + ": Invalid port.")
+	}
+	portString := strings.Split(string(short), "/")[0]
+	parts := strings.Split(portString, ":")
+	port, err := strconv.ParseInt(parts[len(parts)-1], 10, 32)
+	if err != nil {
+		return 0, "", errors.New(string(short) + ": Port ranges and invalid ports are not supported.")
+	}
+	published := ""
+	if len(parts) > 1 {
+		published = parts[len(parts)-2]
+	}
+	return int32(port), published, nil
+}
+
+// ParseComposeSecretReference returns the name of a secret referenced by a service of a docker-compose file. Entries
+// are either the name itself or objects with the name as source.
+func ParseComposeSecretReference(entry json.RawMessage) (string, error) {
+	var long struct {
+		Source string `json:"source"`
+	}
+	if json.Unmarshal(entry, &long) == nil && long.Source != "" {
+		return long.Source, nil
+	}
+
+	var short string
+	err := json.Unmarshal(entry, &short)
+	if err != nil {
+		return "", errors.New(string(entry) + ": Invalid secret reference.")
+	}
+	return short, nil
+}
+
+// ConvertComposeMemory converts a byte value of a docker-compose file (e.g. 512m or 1gb) to a Kubernetes quantity.
+func ConvertComposeMemory(value string) string {
+	units := map[string]string{"b": "", "k": "Ki", "kb": "Ki", "m": "Mi", "mb": "Mi", "g": "Gi", "gb": "Gi"}
+	match := regexp.MustCompile(`^([0-9.]+)\s*([a-zA-Z]*)$`).FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return value
+	}
+	if unit, ok := units[strings.ToLower(match[2])]; ok {
+		return match[1] + unit
+	}
+	return value
+}
+
+// ConvertComposeName converts the name of a service or secret of a docker-compose file to a valid Kubernetes name.
+func ConvertComposeName(name string) string {
+	name = regexp.MustCompile(`[^a-z0-9-]+`).ReplaceAllString(strings.ToLower(name), "-")
+	return strings.Trim(name, "-")
+}
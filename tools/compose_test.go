@@ -0,0 +1,110 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package tools
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestUnsupportedComposeKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+		values  map[string]interface{}
+		want    []string
+	}{
+		{"supported top level keys", "", map[string]interface{}{"version": "3", "services": nil}, nil},
+		{"unsupported top level key", "", map[string]interface{}{"networks": nil}, []string{"x.networks"}},
+		{"container name of a service", "service", map[string]interface{}{"image": "nginx", "container_name": "web"}, nil},
+		{"unsupported service key", "service", map[string]interface{}{"build": "."}, []string{"x.build"}},
+		{"unsupported deploy key", "deploy", map[string]interface{}{"replicas": 2, "placement": nil}, []string{"x.placement"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := unsupportedComposeKeys(test.section, test.values, "x."); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("unsupportedComposeKeys() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseComposePort(t *testing.T) {
+	tests := []struct {
+		name          string
+		entry         string
+		wantPort      int32
+		wantPublished string
+		wantErr       bool
+	}{
+		{"number", `80`, 80, "", false},
+		{"container port only", `"80"`, 80, "", false},
+		{"same published port", `"8080:8080"`, 8080, "8080", false},
+		{"different published port", `"8080:80"`, 80, "8080", false},
+		{"ip and protocol", `"127.0.0.1:5353:53/udp"`, 53, "5353", false},
+		{"long syntax", `{"target": 80, "published": 8080}`, 80, "8080", false},
+		{"port range", `"3000-3005"`, 0, "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			port, published, err := ParseComposePort(json.RawMessage(test.entry))
+			if (err != nil) != test.wantErr {
+				t.Fatalf("ParseComposePort() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if port != test.wantPort || published != test.wantPublished {
+				t.Errorf("ParseComposePort() = %v, %q, want %v, %q", port, published, test.wantPort, test.wantPublished)
+			}
+		})
+	}
+}
+
+func TestExpandComposeVariables(t *testing.T) {
+	t.Setenv("KUFAST_TEST_USER", "bob")
+	t.Setenv("KUFAST_TEST_EMPTY", "")
+	variables := map[string]string{"TAG": "1.25", "USER": "alice"}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"braced variable", "image: nginx:${TAG}", "image: nginx:1.25"},
+		{"plain variable", "image: nginx:$TAG", "image: nginx:1.25"},
+		{".env file before environment", "user: ${USER}", "user: alice"},
+		{"environment", "user: ${KUFAST_TEST_USER}", "user: bob"},
+		{"default for unset variable", "port: ${KUFAST_TEST_UNSET:-8080}", "port: 8080"},
+		{"default for empty variable", "port: ${KUFAST_TEST_EMPTY:-8080}", "port: 8080"},
+		{"default not used", "image: nginx:${TAG:-latest}", "image: nginx:1.25"},
+		{"unset variable", "user: ${KUFAST_TEST_UNSET}", "user: "},
+		{"escaped dollar sign", "command: echo $$HOME", "command: echo $HOME"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := expandComposeVariables(test.content, variables); got != test.want {
+				t.Errorf("expandComposeVariables() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package tools
+
+import (
+	"errors"
+	"github.com/spf13/cobra"
+	"os"
+	"sigs.k8s.io/yaml"
+	"strconv"
+	"strings"
+)
+
+// AddPodFlags registers all flags describing the container of a pod on a cobra command. These flags are shared by all
+// commands that create or update workloads. If withDefaults is false, no default values are set, so only flags
+// explicitly set by the user change an existing workload.
+func AddPodFlags(cmd *cobra.Command, withDefaults bool) {
+	memory, cpu, storage := "500Mi", "500m", "1Gi"
+	if !withDefaults {
+		memory, cpu, storage = "", "", ""
+	}
+
+	cmd.Flags().StringP("memory", "", memory, "The amount of RAM the pod can use")
+	cmd.Flags().StringP("cpu", "", cpu, "The amount of CPU the pod can use")
+	cmd.Flags().StringP("storage", "", storage, "The amount of storage the pod can use")
+	cmd.Flags().StringP("memory-limit", "", "", "The maximum amount of RAM the pod can use. Overrides --memory.")
+	cmd.Flags().StringP("cpu-limit", "", "", "The maximum amount of CPU the pod can use. Overrides --cpu.")
+	cmd.Flags().StringP("storage-limit", "", "", "The maximum amount of storage the pod can use. Overrides --storage.")
+	cmd.Flags().StringP("memory-request", "", "", "The amount of RAM reserved for the pod. Overrides --memory. "+
+		"Requests lower than the limits make the pod burstable.")
+	cmd.Flags().StringP("cpu-request", "", "", "The amount of CPU reserved for the pod. Overrides --cpu. "+
+		"Requests lower than the limits make the pod burstable.")
+	cmd.Flags().StringP("storage-request", "", "", "The amount of storage reserved for the pod. Overrides --storage.")
+	cmd.Flags().StringP("deploy-secret", "d", "", "The name of the deployment secret to deploy this container. This secret will be used to pull the image.")
+	cmd.Flags().StringArrayP("secrets", "s", []string{}, "List of secret names to be introduced in the container "+
+		"as environment variables. The variable name will equal the name of the secret. Can be specified multiple times.")
+	cmd.Flags().Int32SliceP("port", "p", []int32{}, "A port the pod should expose. Can be specified multiple times.")
+	cmd.Flags().StringArrayP("cmd", "", []string{}, "An initial command to be issued at pod start. Required by a few containers.")
+	cmd.Flags().StringArrayP("secret-env", "", []string{}, "An environment variable set to a key of a secret in the format "+
+		"NAME=<secret>:<key>. Can be specified multiple times.")
+	cmd.Flags().StringArrayP("secret-mount", "", []string{}, "A secret to be mounted as read-only files in the format <secret>:<path>. "+
+		"Can be specified multiple times.")
+	cmd.Flags().StringArrayP("volume", "v", []string{}, "A volume to be mounted in the format <volume>:<path>. "+
+		"Data written to the path is kept, when the pod restarts. Can be specified multiple times.")
+	cmd.Flags().StringArrayP("args", "", []string{}, "Arguments passed to the command of the container. "+
+		"Can be specified multiple times, one argument each.")
+	cmd.Flags().StringP("workdir", "", "", "The working directory of the container. Defaults to the one of the image.")
+	cmd.Flags().Int64P("run-as-user", "", -1, "The user ID the container is run with. Defaults to the user of the image.")
+	cmd.Flags().StringP("pull-policy", "", "", "When the image is pulled, either Always, IfNotPresent or Never. "+
+		"Defaults to Always for the latest tag, otherwise IfNotPresent.")
+	cmd.Flags().StringP("liveness", "", "", "A probe restarting the container, if it fails. Either http:<port><path>, "+
+		"tcp:<port> or exec:<command>, e.g. http:8080/healthz. An empty value removes the probe.")
+	cmd.Flags().StringP("readiness", "", "", "A probe marking the container as not ready, if it fails. Same format as --liveness.")
+	cmd.Flags().StringP("startup", "", "", "A probe delaying the other probes until it succeeds once. Same format as --liveness.")
+	cmd.Flags().Int32P("probe-delay", "", 0, "Seconds after the container start before the probes are started.")
+	cmd.Flags().Int32P("probe-period", "", 10, "Seconds between two checks of a probe.")
+	cmd.Flags().Int32P("probe-failures", "", 3, "Number of consecutive failed checks until a probe fails.")
+	cmd.Flags().StringArrayP("env", "e", []string{}, "An environment variable in the format KEY=VALUE. Can be specified multiple times.")
+	cmd.Flags().StringP("env-file", "", "", "A .env file with one environment variable in the format KEY=VALUE per line.")
+	cmd.Flags().StringArrayP("configmap-env", "", []string{}, "A configmap, whose keys are introduced as environment variables. "+
+		"Can be specified multiple times.")
+	cmd.Flags().StringArrayP("configmap-mount", "", []string{}, "A configmap to be mounted as files in the format <configmap>:<path>. "+
+		"Can be specified multiple times.")
+}
+
+// AddJobFlags registers all flags describing the execution of a job on a cobra command. These flags are shared by the
+// commands creating jobs and cronjobs.
+func AddJobFlags(cmd *cobra.Command) {
+	cmd.Flags().Int32P("completions", "", 1, "The number of pods that have to complete successfully.")
+	cmd.Flags().Int32P("parallelism", "", 1, "The number of pods that may run at the same time.")
+	cmd.Flags().Int32P("backoff-limit", "", 6, "The number of retries before the job is marked as failed.")
+	cmd.Flags().Int32P("ttl", "", -1, "Seconds after which a finished job is removed automatically. Finished jobs are kept, if negative.")
+}
+
+// AddContainerFlags registers all flags declaring additional containers and init containers of a pod on a cobra
+// command. These flags are shared by all commands that create workloads.
+func AddContainerFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayP("sidecar", "", []string{}, "An additional container in the format <name>=<image>. "+
+		"It gets the resources of --sidecar-cpu, --sidecar-memory and --sidecar-storage. Can be specified multiple times.")
+	cmd.Flags().StringArrayP("init", "", []string{}, "An init container in the format <name>=<image>, which has to complete before "+
+		"the other containers start. It gets the same resources as a sidecar. Can be specified multiple times.")
+	cmd.Flags().StringP("sidecar-memory", "", "100Mi", "The amount of RAM each container of --sidecar and --init can use.")
+	cmd.Flags().StringP("sidecar-cpu", "", "100m", "The amount of CPU each container of --sidecar and --init can use.")
+	cmd.Flags().StringP("sidecar-storage", "", "100Mi", "The amount of storage each container of --sidecar and --init can use.")
+	cmd.Flags().BoolP("sidecar-secrets", "", false, "Introduce the secrets of --secrets also in the containers of --sidecar and --init.")
+	cmd.Flags().StringP("spec-file", "", "", "A YAML or JSON file declaring additional containers and init containers "+
+		"with individual images, commands, resources, ports and secrets.")
+}
+
+// ParseContainerFlag splits a container declared on the command line in the format <name>=<image>.
+func ParseContainerFlag(value string) (string, string, error) {
+	name, image, found := strings.Cut(value, "=")
+	if !found || name == "" || image == "" {
+		return "", "", errors.New(value + ": Container has to be declared as <name>=<image>.")
+	}
+	return name, image, nil
+}
+
+// ReadPodSpecFile reads a pod spec file in YAML or JSON format. Every container of the file needs a name and an image.
+func ReadPodSpecFile(fileName string) (*PodSpecFile, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var specFile PodSpecFile
+	err = yaml.UnmarshalStrict(content, &specFile)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, container := range append(specFile.Containers, specFile.InitContainers...) {
+		if container.Name == "" || container.Image == "" {
+			return nil, errors.New(fileName + ": Every container needs a name and an image.")
+		}
+	}
+
+	return &specFile, nil
+}
+
+// ParseKeyValue splits a value in the format KEY=VALUE. The value may be empty.
+func ParseKeyValue(value string) (string, string, error) {
+	key, val, found := strings.Cut(value, "=")
+	key = strings.TrimSpace(key)
+	if !found || key == "" {
+		return "", "", errors.New(value + ": Value has to be in the format KEY=VALUE.")
+	}
+	return key, val, nil
+}
+
+// ParseSecretKeyRef splits a reference to a key of a secret in the format NAME=<secret>:<key>.
+func ParseSecretKeyRef(value string) (string, string, string, error) {
+	name, ref, found := strings.Cut(value, "=")
+	secretName, key, refFound := strings.Cut(ref, ":")
+	if !found || !refFound || name == "" || secretName == "" || key == "" {
+		return "", "", "", errors.New(value + ": Secret reference has to be in the format NAME=<secret>:<key>.")
+	}
+	return name, secretName, key, nil
+}
+
+// ParseProbeFlag splits a probe declared on the command line in its kind (http, tcp or exec) and its parameters. HTTP
+// probes are declared as http:<port><path>, TCP probes as tcp:<port> and exec probes as exec:<command>. For exec
+// probes, the command is returned as path.
+func ParseProbeFlag(value string) (string, int32, string, error) {
+	kind, target, _ := strings.Cut(value, ":")
+	invalid := errors.New(value + ": Probe has to be http:<port><path>, tcp:<port> or exec:<command> with a port between 1 and 65535.")
+
+	switch kind {
+	case "http":
+		portString, path, found := strings.Cut(target, "/")
+		port, err := strconv.ParseInt(portString, 10, 32)
+		if err != nil || port < 1 || port > 65535 {
+			return "", 0, "", invalid
+		}
+		if !found {
+			return kind, int32(port), "/", nil
+		}
+		return kind, int32(port), "/" + path, nil
+	case "tcp":
+		port, err := strconv.ParseInt(target, 10, 32)
+		if err != nil || port < 1 || port > 65535 {
+			return "", 0, "", invalid
+		}
+		return kind, int32(port), "", nil
+	case "exec":
+		if target == "" {
+			return "", 0, "", invalid
+		}
+		return kind, 0, target, nil
+	}
+	return "", 0, "", invalid
+}
+
+// ParseMount splits a mount in the format <name>:<path>. The path must be absolute.
+func ParseMount(value string) (string, string, error) {
+	name, path, found := strings.Cut(value, ":")
+	if !found || name == "" || !strings.HasPrefix(path, "/") {
+		return "", "", errors.New(value + ": Mount has to be in the format <name>:<absolute path>.")
+	}
+	return name, path, nil
+}
+
+// ReadEnvFile reads a .env file and returns its variables in the format KEY=VALUE. Empty lines and comments are
+// skipped, an optional "export" prefix and quotes around the value are removed.
+func ReadEnvFile(fileName string) ([]string, error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var variables []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, err := ParseKeyValue(line)
+		if err != nil {
+			return nil, errors.New(fileName + ": " + err.Error())
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		variables = append(variables, key+"="+value)
+	}
+
+	return variables, nil
+}
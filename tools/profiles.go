@@ -0,0 +1,144 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package tools
+
+import (
+	"errors"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/util/homedir"
+	"os"
+	"path/filepath"
+	"sigs.k8s.io/yaml"
+	"strconv"
+	"strings"
+)
+
+// ReadLocalProfiles returns all pod profiles stored in the local profiles file of the user. An empty map is returned,
+// if the file does not exist yet.
+func ReadLocalProfiles() (map[string]PodProfile, error) {
+	profiles := map[string]PodProfile{}
+
+	content, err := os.ReadFile(homedir.HomeDir() + KUFAST_LOCAL_PROFILES_FILE)
+	if errors.Is(err, os.ErrNotExist) {
+		return profiles, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = yaml.UnmarshalStrict(content, &profiles)
+	if err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// WriteLocalProfiles replaces the local profiles file of the user with the pod profiles provided.
+func WriteLocalProfiles(profiles map[string]PodProfile) error {
+	fileName := homedir.HomeDir() + KUFAST_LOCAL_PROFILES_FILE
+	err := os.MkdirAll(filepath.Dir(fileName), 0700)
+	if err != nil {
+		return err
+	}
+
+	content, err := yaml.Marshal(profiles)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, content, 0600)
+}
+
+// ParseProfile reads a single pod profile in YAML format, e.g. from a configmap.
+func ParseProfile(profileName string, content string) (*PodProfile, error) {
+	var profile PodProfile
+	err := yaml.UnmarshalStrict([]byte(content), &profile)
+	if err != nil {
+		return nil, errors.New(profileName + ": Invalid profile. " + err.Error())
+	}
+	return &profile, nil
+}
+
+// NewProfileFromFlags creates a pod profile from the pod flags set explicitly on the command line.
+func NewProfileFromFlags(cmd *cobra.Command) PodProfile {
+	var profile PodProfile
+	profile.Image, _ = cmd.Flags().GetString("image")
+	if cmd.Flags().Changed("cpu") {
+		profile.Cpu, _ = cmd.Flags().GetString("cpu")
+	}
+	if cmd.Flags().Changed("memory") {
+		profile.Memory, _ = cmd.Flags().GetString("memory")
+	}
+	if cmd.Flags().Changed("storage") {
+		profile.Storage, _ = cmd.Flags().GetString("storage")
+	}
+	profile.CpuRequest, _ = cmd.Flags().GetString("cpu-request")
+	profile.MemoryRequest, _ = cmd.Flags().GetString("memory-request")
+	profile.Secrets, _ = cmd.Flags().GetStringArray("secrets")
+	profile.Ports, _ = cmd.Flags().GetInt32Slice("port")
+	profile.DeploySecret, _ = cmd.Flags().GetString("deploy-secret")
+	profile.Cmd, _ = cmd.Flags().GetStringArray("cmd")
+	profile.Restart, _ = cmd.Flags().GetString("restart")
+	return profile
+}
+
+// ApplyProfileToFlags sets the flags of a command to the values of a pod profile. Flags set explicitly on the command
+// line take precedence over the profile and stay untouched. This includes --keep-alive, which replaces the restart
+// policy of the profile.
+func ApplyProfileToFlags(cmd *cobra.Command, profile PodProfile) error {
+	var ports []string
+	for _, port := range profile.Ports {
+		ports = append(ports, strconv.Itoa(int(port)))
+	}
+
+	values := map[string][]string{
+		"cpu":            {profile.Cpu},
+		"memory":         {profile.Memory},
+		"storage":        {profile.Storage},
+		"cpu-request":    {profile.CpuRequest},
+		"memory-request": {profile.MemoryRequest},
+		"secrets":        profile.Secrets,
+		"port":           {strings.Join(ports, ",")},
+		"deploy-secret":  {profile.DeploySecret},
+		"cmd":            profile.Cmd,
+		"restart":        {profile.Restart},
+	}
+	for flagName, flagValues := range values {
+		if cmd.Flags().Lookup(flagName) == nil || cmd.Flags().Changed(flagName) {
+			continue
+		}
+		if flagName == "restart" && cmd.Flags().Changed("keep-alive") {
+			continue
+		}
+		for _, value := range flagValues {
+			if value == "" {
+				continue
+			}
+			err := cmd.Flags().Set(flagName, value)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
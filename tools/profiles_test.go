@@ -0,0 +1,67 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package tools
+
+import (
+	"github.com/spf13/cobra"
+	"testing"
+)
+
+func TestApplyProfileToFlags(t *testing.T) {
+	profile := PodProfile{Cpu: "2", Memory: "1Gi", Ports: []int32{80, 443}, Restart: "OnFailure"}
+
+	tests := []struct {
+		name string
+		args []string
+		flag string
+		want string
+	}{
+		{"profile fills unset flag", []string{}, "cpu", "2"},
+		{"command line takes precedence", []string{"--cpu", "500m"}, "cpu", "500m"},
+		{"ports are joined", []string{}, "port", "[80,443]"},
+		{"restart policy of the profile", []string{}, "restart", "OnFailure"},
+		{"keep-alive replaces restart policy", []string{"--keep-alive"}, "restart", ""},
+		{"empty profile value keeps default", []string{}, "storage", "1Gi"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			AddPodFlags(cmd, true)
+			cmd.Flags().BoolP("keep-alive", "", false, "")
+			cmd.Flags().StringP("restart", "", "", "")
+			err := cmd.ParseFlags(test.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = ApplyProfileToFlags(cmd, profile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := cmd.Flags().Lookup(test.flag).Value.String(); got != test.want {
+				t.Errorf("--%s = %q, want %q", test.flag, got, test.want)
+			}
+		})
+	}
+}
@@ -23,8 +23,96 @@ SOFTWARE.
 */
 package tools
 
+import (
+	v1 "k8s.io/api/core/v1"
+	"time"
+)
+
 // Target represents a deployment target and contains its name and the type of access (either group or node)
 type Target struct {
 	Name       string
 	AccessType string
 }
+
+// NodeInfo represents the inventory of a node as seen by kufast. It contains the nodes targets, its state, its resources
+// and the tenants that are able to deploy to it.
+type NodeInfo struct {
+	Name          string
+	Hostname      string
+	Groups        []string
+	Ready         bool
+	Unschedulable bool
+	Allocatable   v1.ResourceList
+	Requested     v1.ResourceList
+	Taints        []v1.Taint
+	Tenants       []string
+	TenantPods    int
+}
+
+// TargetGroupImpact represents the impact of a change of a target-group. It contains the tenants with access to the
+// group, the tenant-targets deploying to it and the pods that lose their node.
+type TargetGroupImpact struct {
+	Tenants       []string
+	TenantTargets []string
+	Pods          []v1.Pod
+}
+
+// ContainerSpec represents a single container within a pod spec file. Resources, ports and secrets follow the same
+// notation as the flags of the create pod command.
+type ContainerSpec struct {
+	Name    string   `json:"name"`
+	Image   string   `json:"image"`
+	Cmd     []string `json:"cmd,omitempty"`
+	Cpu     string   `json:"cpu,omitempty"`
+	Memory  string   `json:"memory,omitempty"`
+	Storage string   `json:"storage,omitempty"`
+	Ports   []int32  `json:"ports,omitempty"`
+	Secrets []string `json:"secrets,omitempty"`
+}
+
+// PodSpecFile represents a pod spec file, which declares additional containers and init containers of a pod.
+type PodSpecFile struct {
+	Containers     []ContainerSpec `json:"containers,omitempty"`
+	InitContainers []ContainerSpec `json:"initContainers,omitempty"`
+}
+
+// PodRevision represents a pod specification applied by kufast. Revisions are stored in the revision history of a pod,
+// so the pod can be rolled back to them.
+type PodRevision struct {
+	Revision int               `json:"revision"`
+	Created  time.Time         `json:"created"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Spec     v1.PodSpec        `json:"spec"`
+}
+
+// PodProfile represents reusable settings of the create pod command. Resources, ports and secrets follow the same
+// notation as the flags of the create pod command.
+type PodProfile struct {
+	Image         string   `json:"image,omitempty"`
+	Cpu           string   `json:"cpu,omitempty"`
+	Memory        string   `json:"memory,omitempty"`
+	Storage       string   `json:"storage,omitempty"`
+	CpuRequest    string   `json:"cpuRequest,omitempty"`
+	MemoryRequest string   `json:"memoryRequest,omitempty"`
+	Secrets       []string `json:"secrets,omitempty"`
+	Ports         []int32  `json:"ports,omitempty"`
+	DeploySecret  string   `json:"deploySecret,omitempty"`
+	Cmd           []string `json:"cmd,omitempty"`
+	Restart       string   `json:"restart,omitempty"`
+}
+
+// ManifestResult represents the outcome of applying a single object of a Kubernetes manifest.
+type ManifestResult struct {
+	Kind    string
+	Name    string
+	Result  string
+	Message string
+}
+
+// TenantStatus represents the state of all tenant-targets of a tenant as shown by the status dashboard. It contains the
+// pods, the quotas by tenant-target and the latest events.
+type TenantStatus struct {
+	Pods   []v1.Pod
+	Quotas map[string]v1.ResourceQuota
+	Events []v1.Event
+}
@@ -64,6 +64,12 @@ func GetTenantFromNamespace(namespaceName string) string {
 	return strings.Split(namespaceName, ("-"))[0]
 }
 
+// GetTargetFromNamespace returns the targets name from a tenant-target namespace by leveraging
+// the namespace naming convention
+func GetTargetFromNamespace(namespaceName string) string {
+	return strings.TrimPrefix(namespaceName, GetTenantFromNamespace(namespaceName)+"-")
+}
+
 // GetNamespaceFromUserConfig reads the userconfig of a user and returns the namespace
 // specified in it.
 func GetNamespaceFromUserConfig(cmd *cobra.Command) (string, error) {
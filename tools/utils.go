@@ -26,15 +26,21 @@ package tools
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"github.com/briandowns/spinner"
+	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/spf13/cobra"
 	"golang.org/x/term"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -58,6 +64,68 @@ const KUFAST_NODE_GROUP_LABEL = "kufast.group/"
 // KUFAST_TENANT_LABEL returns the default label for a tenant object
 const KUFAST_TENANT_LABEL = "kufast/tenant"
 
+// KUFAST_TENANT_PLACEMENT_ANNOTATION returns the static part of the placement policy annotation of a tenant
+const KUFAST_TENANT_PLACEMENT_ANNOTATION = "kufast.placement/"
+
+// KUFAST_TENANT_STORAGECLASS_ANNOTATION returns the static part of the annotation holding the storage classes a tenant
+// may use for volumes in a tenant-target
+const KUFAST_TENANT_STORAGECLASS_ANNOTATION = "kufast.storageclasses/"
+
+// KUFAST_STORAGECLASS_QUOTA_SUFFIX returns the suffix of the quota resources limiting the storage requested per
+// storage class
+const KUFAST_STORAGECLASS_QUOTA_SUFFIX = ".storageclass.storage.k8s.io/requests.storage"
+
+// KUFAST_TENANT_SECURITY_ANNOTATION returns the static part of the annotation holding the pod security level of a
+// tenant-target
+const KUFAST_TENANT_SECURITY_ANNOTATION = "kufast.security/"
+
+// KUFAST_POD_SECURITY_LABEL returns the static part of the PodSecurity admission labels of a namespace
+const KUFAST_POD_SECURITY_LABEL = "pod-security.kubernetes.io/"
+
+// KUFAST_HISTORY_ANNOTATION returns the annotation of a pod, which holds the revision history of the pod
+const KUFAST_HISTORY_ANNOTATION = "kufast/history"
+
+// KUFAST_HISTORY_LIMIT returns the number of revisions kept in the history of a pod
+const KUFAST_HISTORY_LIMIT = 10
+
+// KUFAST_HISTORY_MAX_SIZE returns the maximum size of the revision history of a pod in bytes. This is half of the size
+// Kubernetes allows for all annotations of an object.
+const KUFAST_HISTORY_MAX_SIZE = 128 * 1024
+
+// KUFAST_STATUS_EVENT_LIMIT returns the number of events shown in the event feed of the status dashboard
+const KUFAST_STATUS_EVENT_LIMIT = 10
+
+// KUFAST_PROFILES_CONFIGMAP returns the name of the configmap holding the pod profiles shared within a tenant-target
+const KUFAST_PROFILES_CONFIGMAP = "kufast-profiles"
+
+// KUFAST_PROFILES_LABEL returns the label marking the configmap holding the pod profiles shared within a tenant-target
+const KUFAST_PROFILES_LABEL = "kufast/profiles"
+
+// KUFAST_LOCAL_PROFILES_FILE returns the location of the file holding the local pod profiles relative to the home
+// directory of the user
+const KUFAST_LOCAL_PROFILES_FILE = "/.kufast/profiles.yaml"
+
+// KUFAST_TENANT_DOMAINS_ANNOTATION returns the annotation holding the domain suffixes a tenant may use for ingresses
+const KUFAST_TENANT_DOMAINS_ANNOTATION = "kufast/domains"
+
+// KUFAST_WORKLOAD_LABEL returns the label that connects pods to their workload (pod or deployment)
+const KUFAST_WORKLOAD_LABEL = "kufast/workload"
+
+// KUFAST_EXPOSED_LABEL returns the label of pods that are reachable from outside of the tenant through a NodePort
+const KUFAST_EXPOSED_LABEL = "kufast/exposed"
+
+// KUFAST_CLUSTER_DOMAIN returns the DNS domain of the cluster used for the names of services
+const KUFAST_CLUSTER_DOMAIN = "svc.cluster.local"
+
+// KUFAST_TARGET_GROUP_LABEL returns the label for kufast objects that belong to a target-group
+const KUFAST_TARGET_GROUP_LABEL = "kufast/target-group"
+
+// KUFAST_NODE_SELECTOR_ANNOTATION returns the annotation of the PodNodeSelector admission plugin set on tenant-targets
+const KUFAST_NODE_SELECTOR_ANNOTATION = "scheduler.alpha.kubernetes.io/node-selector"
+
+// KUFAST_KEEPALIVE_LABEL returns the label that marks pods, which are restarted upon termination (--keep-alive)
+const KUFAST_KEEPALIVE_LABEL = "kufast/keep-alive"
+
 // HandleError prints the error message given to it, prints the cobra commands help and exits the program
 func HandleError(err error, cmd *cobra.Command) {
 	fmt.Println("\n\n" + err.Error() + "\n\n")
@@ -166,3 +234,320 @@ func CreateStandardSpinner(message string) *spinner.Spinner {
 func IsAlphaNumeric(s string) bool {
 	return regexp.MustCompile(`^[a-zA-Z0-9]*$`).MatchString(s)
 }
+
+// IsValidPlacementPolicy returns true, if the string is a placement policy kufast can apply to pods.
+func IsValidPlacementPolicy(s string) bool {
+	return s == "none" || s == "spread" || s == "pack"
+}
+
+// KUFAST_DEFAULT_SECURITY_LEVEL returns the pod security level of new tenant-targets, if no level has been chosen
+const KUFAST_DEFAULT_SECURITY_LEVEL = "restricted"
+
+// IsValidSecurityLevel returns true, if the string is a pod security level of the Kubernetes Pod Security Standards.
+func IsValidSecurityLevel(s string) bool {
+	return s == "privileged" || s == "baseline" || s == "restricted"
+}
+
+// IsValidLimitRequestRatio returns true, if the string is empty or a quantity of at least 1, as Kubernetes requires for
+// the maximum ratio between the limits and requests of a container.
+func IsValidLimitRequestRatio(s string) bool {
+	if s == "" {
+		return true
+	}
+	qty, err := resource.ParseQuantity(s)
+	return err == nil && qty.Cmp(resource.MustParse("1")) >= 0
+}
+
+// ParseAccessMode returns the access mode of a persistent volume from its short (rwo, rox, rwx) or full notation.
+func ParseAccessMode(accessMode string) (v1.PersistentVolumeAccessMode, error) {
+	switch strings.ToLower(accessMode) {
+	case "rwo", "readwriteonce":
+		return v1.ReadWriteOnce, nil
+	case "rox", "readonlymany":
+		return v1.ReadOnlyMany, nil
+	case "rwx", "readwritemany":
+		return v1.ReadWriteMany, nil
+	}
+	return "", errors.New(accessMode + ": Access mode has to be one of rwo, rox or rwx.")
+}
+
+// ParseRestartPolicy returns the restart policy of a pod from its name (Always, OnFailure or Never). The case of the
+// name is ignored.
+func ParseRestartPolicy(policy string) (v1.RestartPolicy, error) {
+	switch strings.ToLower(policy) {
+	case "always":
+		return v1.RestartPolicyAlways, nil
+	case "onfailure":
+		return v1.RestartPolicyOnFailure, nil
+	case "never":
+		return v1.RestartPolicyNever, nil
+	}
+	return "", errors.New(policy + ": Restart policy has to be one of Always, OnFailure or Never.")
+}
+
+// ParsePullPolicy returns the image pull policy of a container from its name (Always, IfNotPresent or Never). The case
+// of the name is ignored.
+func ParsePullPolicy(policy string) (v1.PullPolicy, error) {
+	switch strings.ToLower(policy) {
+	case "always":
+		return v1.PullAlways, nil
+	case "ifnotpresent":
+		return v1.PullIfNotPresent, nil
+	case "never":
+		return v1.PullNever, nil
+	}
+	return "", errors.New(policy + ": Pull policy has to be one of Always, IfNotPresent or Never.")
+}
+
+// IsAllowedDomain returns true, if the hostname equals one of the domain suffixes or is a subdomain of it.
+func IsAllowedDomain(hostname string, allowedDomains []string) bool {
+	hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+	for _, domain := range allowedDomains {
+		domain = strings.ToLower(strings.Trim(domain, "."))
+		if domain == "" {
+			continue
+		}
+		if hostname == domain || strings.HasSuffix(hostname, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatResourceUsage returns a human readable string of the used amount of a resource compared to the
+// available amount of this resource.
+func FormatResourceUsage(used v1.ResourceList, available v1.ResourceList, name v1.ResourceName) string {
+	usedQty := used[name]
+	availableQty := available[name]
+	return usedQty.String() + " / " + availableQty.String()
+}
+
+// FormatNodeStatus returns the status of a node in the same notation as kubectl.
+func FormatNodeStatus(node NodeInfo) string {
+	status := "NotReady"
+	if node.Ready {
+		status = "Ready"
+	}
+	if node.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	return status
+}
+
+// FormatJobStatus returns the status of a job run as a single word together with the number of succeeded pods.
+func FormatJobStatus(job batchv1.Job) string {
+	status := "Pending"
+	if job.Status.Active > 0 {
+		status = "Running"
+	}
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != v1.ConditionTrue {
+			continue
+		}
+		if condition.Type == batchv1.JobComplete {
+			status = "Complete"
+		} else if condition.Type == batchv1.JobFailed {
+			status = "Failed"
+		}
+	}
+
+	completions := int32(1)
+	if job.Spec.Completions != nil {
+		completions = *job.Spec.Completions
+	}
+	return fmt.Sprintf("%s (%d/%d)", status, job.Status.Succeeded, completions)
+}
+
+// FormatServiceAddresses returns the addresses a service can be reached at. These are the DNS name within the cluster
+// and, for NodePort services, the port on every node.
+func FormatServiceAddresses(service v1.Service) string {
+	var addresses []string
+	for _, port := range service.Spec.Ports {
+		address := service.Name + "." + service.Namespace + "." + KUFAST_CLUSTER_DOMAIN + ":" + strconv.Itoa(int(port.Port))
+		if port.NodePort != 0 {
+			address += " (NodePort " + strconv.Itoa(int(port.NodePort)) + ")"
+		}
+		addresses = append(addresses, address)
+	}
+	return strings.Join(addresses, "\n")
+}
+
+// FormatContainerStates returns the state of every init container and container of a pod, one per line.
+func FormatContainerStates(pod v1.Pod) string {
+	var states []string
+	for _, status := range pod.Status.InitContainerStatuses {
+		states = append(states, "init "+status.Name+": "+FormatContainerState(status.State))
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		readiness := "not ready"
+		if status.Ready {
+			readiness = "ready"
+		}
+		states = append(states, status.Name+": "+FormatContainerState(status.State)+", "+readiness)
+	}
+	return strings.Join(states, "\n")
+}
+
+// FormatRunAsUser returns the user ID a container is run with, or "Image default", if none is set.
+func FormatRunAsUser(container *v1.Container) string {
+	if container.SecurityContext == nil || container.SecurityContext.RunAsUser == nil {
+		return "Image default"
+	}
+	return strconv.FormatInt(*container.SecurityContext.RunAsUser, 10)
+}
+
+// FormatContainerResources returns the limits and requests of CPU and memory of a container, e.g.
+// "cpu 500m/250m, memory 500Mi/500Mi" (limit/request).
+func FormatContainerResources(container v1.Container) string {
+	cpuLimit := container.Resources.Limits["cpu"]
+	cpuRequest := container.Resources.Requests["cpu"]
+	memoryLimit := container.Resources.Limits["memory"]
+	memoryRequest := container.Resources.Requests["memory"]
+	return "cpu " + cpuLimit.String() + "/" + cpuRequest.String() +
+		", memory " + memoryLimit.String() + "/" + memoryRequest.String()
+}
+
+// FormatContainerPorts returns the ports of a container as comma separated list.
+func FormatContainerPorts(container v1.Container) string {
+	var ports []string
+	for _, port := range container.Ports {
+		ports = append(ports, strconv.Itoa(int(port.ContainerPort)))
+	}
+	return strings.Join(ports, ",")
+}
+
+// FormatContainerSecrets returns the names of all secrets a container references in its environment variables as
+// comma separated list.
+func FormatContainerSecrets(container v1.Container) string {
+	var secrets []string
+	for _, envVar := range container.Env {
+		if envVar.ValueFrom != nil && envVar.ValueFrom.SecretKeyRef != nil {
+			secrets = append(secrets, envVar.ValueFrom.SecretKeyRef.Name)
+		}
+	}
+	return strings.Join(secrets, ",")
+}
+
+// FormatPodReadiness returns the number of ready containers out of all containers of a pod, e.g. "1/2".
+func FormatPodReadiness(pod v1.Pod) string {
+	ready := 0
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Ready {
+			ready++
+		}
+	}
+	return strconv.Itoa(ready) + "/" + strconv.Itoa(len(pod.Spec.Containers))
+}
+
+// FormatPodRestarts returns the number of restarts of all containers of a pod.
+func FormatPodRestarts(pod v1.Pod) string {
+	restarts := int32(0)
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		restarts += status.RestartCount
+	}
+	return strconv.Itoa(int(restarts))
+}
+
+// FormatAge returns the time passed since a point in time in its largest unit, e.g. "5m" or "3d".
+func FormatAge(since time.Time) string {
+	if since.IsZero() {
+		return "-"
+	}
+	age := time.Since(since)
+	switch {
+	case age < time.Minute:
+		return strconv.Itoa(int(age.Seconds())) + "s"
+	case age < time.Hour:
+		return strconv.Itoa(int(age.Minutes())) + "m"
+	case age < 24*time.Hour:
+		return strconv.Itoa(int(age.Hours())) + "h"
+	}
+	return strconv.Itoa(int(age.Hours()/24)) + "d"
+}
+
+// GetEventTime returns the time an event has been reported last.
+func GetEventTime(event v1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// FormatUsageBar returns the usage of a resource as bar followed by the percentage and the amounts, e.g.
+// "[##########----------]  50% 512Mi/1Gi".
+func FormatUsageBar(used resource.Quantity, hard resource.Quantity) string {
+	width := 20
+	ratio := 0.0
+	if !hard.IsZero() {
+		ratio = used.AsApproximateFloat64() / hard.AsApproximateFloat64()
+	} else if !used.IsZero() {
+		ratio = 1
+	}
+	filled := int(ratio*float64(width) + 0.5)
+	if filled > width {
+		filled = width
+	}
+	return fmt.Sprintf("[%s%s] %3d%% %s/%s", strings.Repeat("#", filled), strings.Repeat("-", width-filled),
+		int(ratio*100+0.5), used.String(), hard.String())
+}
+
+// FormatProbe returns a short description of a probe, e.g. "http :8080/healthz (delay 0s, period 10s, failures 3)".
+func FormatProbe(probe *v1.Probe) string {
+	if probe == nil {
+		return "None"
+	}
+
+	var check string
+	if probe.HTTPGet != nil {
+		check = "http :" + probe.HTTPGet.Port.String() + probe.HTTPGet.Path
+	} else if probe.TCPSocket != nil {
+		check = "tcp :" + probe.TCPSocket.Port.String()
+	} else if probe.Exec != nil {
+		check = "exec " + strings.Join(probe.Exec.Command, " ")
+	} else {
+		check = "unknown"
+	}
+	return check + " (delay " + strconv.Itoa(int(probe.InitialDelaySeconds)) + "s, period " +
+		strconv.Itoa(int(probe.PeriodSeconds)) + "s, failures " + strconv.Itoa(int(probe.FailureThreshold)) + ")"
+}
+
+// FormatContainerState returns the state of a single container together with its reason, e.g. "Waiting (ErrImagePull)".
+func FormatContainerState(state v1.ContainerState) string {
+	if state.Running != nil {
+		return "Running"
+	}
+	if state.Waiting != nil {
+		return "Waiting (" + state.Waiting.Reason + ")"
+	}
+	if state.Terminated != nil {
+		return "Terminated (" + state.Terminated.Reason + ", exit code " + strconv.Itoa(int(state.Terminated.ExitCode)) + ")"
+	}
+	return "Unknown"
+}
+
+// RenderTargetGroupImpact prints the impact of a change of a target-group to the command line.
+func RenderTargetGroupImpact(groupName string, impact TargetGroupImpact) {
+	fmt.Println("Impact on target-group " + groupName + ":")
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"ATTRIBUTE", "VALUE"})
+	t.AppendRow(table.Row{"Tenants with Group Access", impact.Tenants})
+	t.AppendRow(table.Row{"Tenant-Targets", impact.TenantTargets})
+	t.AppendSeparator()
+	t.Render()
+
+	if len(impact.Pods) > 0 {
+		podTable := table.NewWriter()
+		podTable.SetOutputMirror(os.Stdout)
+		podTable.AppendHeader(table.Row{"UNSCHEDULABLE POD", "TENANT-TARGET", "NODE", "STATUS"})
+		for _, pod := range impact.Pods {
+			podTable.AppendRow(table.Row{pod.Name, pod.Namespace, pod.Spec.NodeName, pod.Status.Phase})
+		}
+		podTable.AppendSeparator()
+		podTable.Render()
+	}
+}
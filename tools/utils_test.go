@@ -0,0 +1,97 @@
+/*
+MIT License
+
+Copyright (c) 2023 Stefan Pawlowski
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package tools
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	"testing"
+	"time"
+)
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		name  string
+		since time.Time
+		want  string
+	}{
+		{"unknown time", time.Time{}, "-"},
+		{"seconds", time.Now().Add(-42 * time.Second), "42s"},
+		{"minutes", time.Now().Add(-90 * time.Second), "1m"},
+		{"hours", time.Now().Add(-5*time.Hour - 30*time.Minute), "5h"},
+		{"days", time.Now().Add(-50 * time.Hour), "2d"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := FormatAge(test.since); got != test.want {
+				t.Errorf("FormatAge() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatUsageBar(t *testing.T) {
+	tests := []struct {
+		name string
+		used string
+		hard string
+		want string
+	}{
+		{"unused", "0", "1Gi", "[--------------------]   0% 0/1Gi"},
+		{"half used", "512Mi", "1Gi", "[##########----------]  50% 512Mi/1Gi"},
+		{"fully used", "2", "2", "[####################] 100% 2/2"},
+		{"over quota", "3", "2", "[####################] 150% 3/2"},
+		{"used without quota", "1", "0", "[####################] 100% 1/0"},
+		{"milli cpu", "250m", "1", "[#####---------------]  25% 250m/1"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := FormatUsageBar(resource.MustParse(test.used), resource.MustParse(test.hard)); got != test.want {
+				t.Errorf("FormatUsageBar() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestIsValidLimitRequestRatio(t *testing.T) {
+	tests := []struct {
+		ratio string
+		want  bool
+	}{
+		{"", true},
+		{"1", true},
+		{"2", true},
+		{"1500m", true},
+		{"500m", false},
+		{"0", false},
+		{"-2", false},
+		{"double", false},
+	}
+	for _, test := range tests {
+		t.Run(test.ratio, func(t *testing.T) {
+			if got := IsValidLimitRequestRatio(test.ratio); got != test.want {
+				t.Errorf("IsValidLimitRequestRatio(%q) = %v, want %v", test.ratio, got, test.want)
+			}
+		})
+	}
+}